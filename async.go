@@ -0,0 +1,120 @@
+package logr_go_client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Overflow defines what an async transport does when its queue is full.
+type Overflow int
+
+const (
+	OverflowBlock      Overflow = iota // wait for a free slot
+	OverflowDropNewest                 // discard the record being pushed
+	OverflowDropOldest                 // discard the oldest queued record
+)
+
+var ErrQueueFull = errors.New("async queue is full")
+var ErrQueueClosed = errors.New("async queue is closed")
+
+type asyncQueue struct {
+	items    chan queueItem
+	overflow Overflow
+	send     func(item queueItem) error
+	pending  int64 // queued and in flight, accessed atomically
+	dropped  uint64
+	mu       sync.RWMutex
+	closed   bool
+	done     chan struct{}
+	errorReporter
+}
+
+func newAsyncQueue(size int, overflow Overflow, send func(item queueItem) error) *asyncQueue {
+	q := &asyncQueue{
+		items:    make(chan queueItem, size),
+		overflow: overflow,
+		send:     send,
+		done:     make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	for item := range q.items {
+		q.report(q.send(item))
+		atomic.AddInt64(&q.pending, -1)
+	}
+}
+
+func (q *asyncQueue) drop() {
+	atomic.AddInt64(&q.pending, -1)
+	atomic.AddUint64(&q.dropped, 1)
+}
+
+func (q *asyncQueue) push(item queueItem) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	atomic.AddInt64(&q.pending, 1)
+	switch q.overflow {
+	case OverflowDropNewest:
+		select {
+		case q.items <- item:
+		default:
+			q.drop()
+			return ErrQueueFull
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.items <- item:
+				return nil
+			default:
+			}
+			select {
+			case <-q.items:
+				q.drop()
+			default:
+			}
+		}
+	default:
+		q.items <- item
+	}
+	return nil
+}
+
+// Dropped returns the number of records discarded by the overflow policy.
+func (q *asyncQueue) Dropped() uint64 {
+	return atomic.LoadUint64(&q.dropped)
+}
+
+func (q *asyncQueue) flush(ctx context.Context) error {
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&q.pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// close stops accepting records and waits until the queued ones are sent.
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.items)
+	}
+	q.mu.Unlock()
+	<-q.done
+}
@@ -0,0 +1,115 @@
+package logr_go_client
+
+import (
+	"context"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"sync"
+	"time"
+)
+
+const DEFAULT_BATCH_WINDOW = 100 * time.Millisecond
+
+// batcher coalesces packages and hands them over to send when size packages
+// are collected or when window has passed since the first one was added.
+// The records the packages were made of are kept along to be handed over to
+// fail when sending does not succeed. The error of a flush made by the timer
+// is returned by the next add or flush.
+type batcher struct {
+	size   int
+	window time.Duration
+	send   func(ctx context.Context, packages []*pb.LogRpcPackage) error
+	fail   func(items []queueItem, err error) error
+	sendMu sync.Mutex // keeps batches in order, taken before mu
+	mu     sync.Mutex
+	buf    []*pb.LogRpcPackage
+	items  []queueItem
+	timer  *time.Timer
+	err    error // of the last flush by the timer
+	errorReporter
+}
+
+func newBatcher(size int, window time.Duration, send func(ctx context.Context, packages []*pb.LogRpcPackage) error) *batcher {
+	if window <= 0 {
+		window = DEFAULT_BATCH_WINDOW
+	}
+	return &batcher{
+		size:   size,
+		window: window,
+		send:   send,
+	}
+}
+
+func (b *batcher) add(p *pb.LogRpcPackage, item queueItem) error {
+	b.mu.Lock()
+	b.buf = append(b.buf, p)
+	b.items = append(b.items, item)
+	full := len(b.buf) >= b.size
+	if !full && b.timer == nil {
+		b.timer = time.AfterFunc(b.window, func() {
+			if err := b.drain(context.Background()); err != nil {
+				b.report(err)
+				b.mu.Lock()
+				b.err = err
+				b.mu.Unlock()
+			}
+		})
+	}
+	b.mu.Unlock()
+	if full {
+		return b.flush(context.Background())
+	}
+	return b.takeErr()
+}
+
+// takeErr returns the error of the last flush by the timer, once.
+func (b *batcher) takeErr() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err := b.err
+	b.err = nil
+	return err
+}
+
+// flush sends the current batch, it returns its error or else the one of
+// the last flush by the timer.
+func (b *batcher) flush(ctx context.Context) error {
+	err := b.drain(ctx)
+	if timerErr := b.takeErr(); err == nil {
+		err = timerErr
+	}
+	return err
+}
+
+// drain sends the packages collected so far, size at a time.
+func (b *batcher) drain(ctx context.Context) error {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	packages, items := b.buf, b.items
+	b.buf, b.items = nil, nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	for len(packages) > 0 {
+		n := b.size
+		if n > len(packages) {
+			n = len(packages)
+		}
+		err := ctx.Err()
+		if err == nil {
+			err = b.send(ctx, packages[:n])
+		}
+		if err != nil {
+			if b.fail != nil {
+				return b.fail(items, err)
+			}
+			return err
+		}
+		packages, items = packages[n:], items[n:]
+	}
+	return nil
+}
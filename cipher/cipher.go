@@ -11,18 +11,54 @@ import (
 	"io"
 )
 
+type Mode string
+
+const (
+	ModeCfb Mode = "cfb" // no integrity check, the default for compatibility
+	ModeGcm Mode = "gcm"
+	// ModeAuto decrypts both: AES-GCM when the ciphertext starts with
+	// VersionGcm, AES-CFB otherwise. A ciphertext starting with VersionGcm
+	// which does not open is ErrIntegrity, unless DecodeAesJsonMode finds it
+	// is the JSON of an older client in AES-CFB. A forged AES-CFB ciphertext
+	// goes unnoticed, a receiver expecting AES-GCM only should use ModeGcm.
+	ModeAuto Mode = ""
+)
+
+// VersionGcm is the first byte of AES-GCM ciphertexts. EncryptAes never
+// starts its output with it, but the older clients did once in 256 times.
+const VersionGcm byte = 0x01
+
+var ErrIntegrity = errors.New("ciphertext integrity check failed")
+
 func EncryptAesJson(data interface{}, priv string) ([]byte, error) {
+	return EncryptAesJsonMode(data, priv, ModeCfb)
+}
+
+func EncryptAesJsonMode(data interface{}, priv string, mode Mode) ([]byte, error) {
 	privBytes, _ := base64.StdEncoding.DecodeString(priv)
 	jsonMsg, err := gojson.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
+	if mode == ModeGcm {
+		return EncryptAesGcm(jsonMsg, privBytes)
+	}
 	return EncryptAes(jsonMsg, privBytes)
 }
 
 func DecodeAesJson(cipherBytes []byte, priv string, dest interface{}) error {
+	return DecodeAesJsonMode(cipherBytes, priv, dest, ModeAuto)
+}
+
+func DecodeAesJsonMode(cipherBytes []byte, priv string, dest interface{}, mode Mode) error {
 	privBytes, _ := base64.StdEncoding.DecodeString(priv)
-	text, err := DecryptAes(cipherBytes, privBytes)
+	text, err := DecryptAesMode(cipherBytes, privBytes, mode)
+	if err == ErrIntegrity && mode == ModeAuto {
+		// the older clients started 1 in 256 AES-CFB ciphertexts with VersionGcm
+		if legacy, cfbErr := decryptAesCfb(append([]byte(nil), cipherBytes...), privBytes); cfbErr == nil && gojson.Valid(legacy) {
+			text, err = legacy, nil
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -47,6 +83,11 @@ func EncryptAes(plainText []byte, key []byte) ([]byte, error) {
 	if _, err = io.ReadFull(rand.Reader, iv); err != nil {
 		return nil, err
 	}
+	for iv[0] == VersionGcm {
+		if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+			return nil, err
+		}
+	}
 
 	stream := cipher.NewCFBEncrypter(block, iv)
 	stream.XORKeyStream(cipherText[aes.BlockSize:], plainText)
@@ -69,7 +110,26 @@ func EncryptAesIv(plainText []byte, key []byte, iv []byte) ([]byte, error) {
 	return cipherText, err
 }
 
+// DecryptAes decrypts the output of EncryptAes and EncryptAesGcm, see ModeAuto.
+// Use ModeCfb for the AES-CFB ciphertexts of the older clients starting with VersionGcm.
 func DecryptAes(cipherText []byte, key []byte) ([]byte, error) {
+	return DecryptAesMode(cipherText, key, ModeAuto)
+}
+
+func DecryptAesMode(cipherText []byte, key []byte, mode Mode) ([]byte, error) {
+	switch mode {
+	case ModeGcm:
+		return DecryptAesGcm(cipherText, key)
+	case ModeCfb:
+		return decryptAesCfb(cipherText, key)
+	}
+	if len(cipherText) > 0 && cipherText[0] == VersionGcm {
+		return DecryptAesGcm(cipherText, key)
+	}
+	return decryptAesCfb(cipherText, key)
+}
+
+func decryptAesCfb(cipherText []byte, key []byte) ([]byte, error) {
 	hash := sha256.Sum256(key)
 	block, err := aes.NewCipher(hash[:])
 	if err != nil {
@@ -92,3 +152,45 @@ func DecryptAes(cipherText []byte, key []byte) ([]byte, error) {
 
 	return cipherText, nil
 }
+
+// EncryptAesGcm encrypts with AES-GCM, the output is VersionGcm, the nonce
+// and the sealed text. Unlike EncryptAes, tampering is detected on decryption.
+func EncryptAesGcm(plainText []byte, key []byte) ([]byte, error) {
+	aead, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, 1+aead.NonceSize(), 1+aead.NonceSize()+len(plainText)+aead.Overhead())
+	head[0] = VersionGcm
+	if _, err = io.ReadFull(rand.Reader, head[1:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(head, head[1:], plainText, head[:1]), nil
+}
+
+// DecryptAesGcm returns ErrIntegrity if the ciphertext was altered, truncated
+// or encrypted with another key.
+func DecryptAesGcm(cipherText []byte, key []byte) ([]byte, error) {
+	aead, err := newGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(cipherText) < 1+aead.NonceSize()+aead.Overhead() || cipherText[0] != VersionGcm {
+		return nil, ErrIntegrity
+	}
+	nonce := cipherText[1 : 1+aead.NonceSize()]
+	plainText, err := aead.Open(nil, nonce, cipherText[1+aead.NonceSize():], cipherText[:1])
+	if err != nil {
+		return nil, ErrIntegrity
+	}
+	return plainText, nil
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	hash := sha256.Sum256(key)
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
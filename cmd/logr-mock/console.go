@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/types"
+	"github.com/fatih/color"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+var colorFaint = color.New(color.Faint).SprintFunc()
+
+// console prints the received logs as they come and sums up the counts
+// until they are printed with printCounts.
+type console struct {
+	mu      sync.Mutex
+	w       io.Writer
+	counts  map[string]*types.Count
+	changed bool
+}
+
+func newConsole(w io.Writer) *console {
+	return &console{w: w, counts: map[string]*types.Count{}}
+}
+
+func (c *console) log(lg *types.Log) {
+	ts := time.Unix(0, lg.Timestamp).Format("15:04:05.000")
+	line := fmt.Sprintf("%s %s %s %s", colorFaint(ts), logr.ColorLevel(types.Level(lg.Level)), colorFaint(lg.Logname), lg.Message)
+	if len(lg.Fields) > 0 {
+		line += " " + colorFaint(lg.Fields.String())
+	}
+	if lg.Initiator != "" {
+		line += " " + colorFaint("("+lg.Initiator+")")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintln(c.w, line)
+}
+
+func (c *console) count(cnt *types.Count) {
+	key := cnt.Logname + "|" + cnt.Labels.Key(cnt.Keyname)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changed = true
+	total, ok := c.counts[key]
+	if !ok {
+		total = &types.Count{Logname: cnt.Logname, Keyname: cnt.Keyname, Labels: cnt.Labels}
+		c.counts[key] = total
+	}
+	total.Metrics.Merge(cnt.Metrics)
+}
+
+// printCounts prints the totals of all the counters, if anything came since the last time.
+func (c *console) printCounts() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.changed {
+		return
+	}
+	c.changed = false
+
+	keys := make([]string, 0, len(c.counts))
+	for key := range c.counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(c.w, colorFaint("--- counters at "+time.Now().Format("15:04:05")+" ---"))
+	tw := tabwriter.NewWriter(c.w, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		cnt := c.counts[key]
+		values := cnt.Metrics.ToMap()
+		kinds := make([]string, 0, len(values))
+		for kind := range values {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		parts := make([]string, len(kinds))
+		for i, kind := range kinds {
+			parts[i] = fmt.Sprintf("%s=%v", kind, values[kind])
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", cnt.Logname, cnt.Labels.Key(cnt.Keyname), strings.Join(parts, " "))
+	}
+	tw.Flush()
+}
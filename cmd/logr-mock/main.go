@@ -0,0 +1,89 @@
+// Command logr-mock is a stand-in for the logr server in local development.
+// It receives logs and counts over UDP and gRPC, decrypts them with the
+// given private key and prints them to the terminal. Without a key only the
+// packages of NoCipher clients are read:
+//
+//	logr-mock -key "$LOGR_PRIVATE_KEY" -udp :7776 -grpc :7777
+package main
+
+import (
+	"flag"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/receiver"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	udpAddr := flag.String("udp", ":7776", "UDP address to listen on, empty to disable")
+	grpcAddr := flag.String("grpc", ":7777", "gRPC address to listen on, empty to disable")
+	key := flag.String("key", os.Getenv("LOGR_PRIVATE_KEY"), "private key, defaults to $LOGR_PRIVATE_KEY, not needed for NoCipher clients")
+	interval := flag.Duration("interval", 10*time.Second, "how often the counters are printed, 0 to disable")
+	flag.Parse()
+
+	if *key == "" {
+		log.Println("logr-mock: no private key, encrypted packages will be refused")
+	}
+	conf := &receiver.Config{PrivateKey: *key, PlainOnly: *key == ""}
+	out := newConsole(os.Stdout)
+
+	if *udpAddr != "" {
+		r, err := conf.Listen(*udpAddr)
+		if err != nil {
+			log.Fatalln("logr-mock:", err)
+		}
+		defer r.Close()
+		log.Println("logr-mock: udp listening on", r.Addr())
+		go func() {
+			for lg := range r.Logs {
+				out.log(lg)
+			}
+		}()
+		go func() {
+			for c := range r.Counts {
+				out.count(c)
+			}
+		}()
+		go func() {
+			for err := range r.Errors {
+				log.Println("logr-mock: udp:", err)
+			}
+		}()
+	}
+
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatalln("logr-mock:", err)
+		}
+		server := grpc.NewServer()
+		pb.RegisterLogRpcServer(server, &logRpcServer{conf: conf, out: out})
+		defer server.Stop()
+		log.Println("logr-mock: grpc listening on", lis.Addr())
+		go func() {
+			if err := server.Serve(lis); err != nil {
+				log.Fatalln("logr-mock:", err)
+			}
+		}()
+	}
+
+	if *interval > 0 {
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+		go func() {
+			for range ticker.C {
+				out.printCounts()
+			}
+		}()
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	out.printCounts()
+}
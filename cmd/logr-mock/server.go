@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/receiver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"strconv"
+	"strings"
+)
+
+type logRpcServer struct {
+	pb.UnimplementedLogRpcServer
+	conf *receiver.Config
+	out  *console
+}
+
+func (s *logRpcServer) handle(p *pb.LogRpcPackage) error {
+	lg, c, err := s.conf.DecodeProto(p)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if lg != nil {
+		s.out.log(lg)
+	}
+	if c != nil {
+		s.out.count(c)
+	}
+	return nil
+}
+
+func (s *logRpcServer) Push(_ context.Context, p *pb.LogRpcPackage) (*pb.Response, error) {
+	return &pb.Response{}, s.handle(p)
+}
+
+// PushBatch prints the packages it can read, the others are reported in the
+// error: a bad one does not take the rest of the batch down.
+func (s *logRpcServer) PushBatch(_ context.Context, b *pb.LogRpcBatch) (*pb.Response, error) {
+	var bad []string
+	var first error
+	for i, p := range b.Packages {
+		if err := s.handle(p); err != nil {
+			bad = append(bad, strconv.Itoa(i))
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	if first != nil {
+		log.Printf("logr-mock: grpc: %d of %d packages refused: %v", len(bad), len(b.Packages), status.Convert(first).Message())
+		return nil, status.Errorf(codes.InvalidArgument, "packages %s refused: %s", strings.Join(bad, ", "), status.Convert(first).Message())
+	}
+	return &pb.Response{}, nil
+}
@@ -1,16 +1,21 @@
 package logr_go_client
 
 import (
+	"github.com/504dev/logr-go-client/cipher"
 	"github.com/504dev/logr-go-client/types"
 	"github.com/504dev/logr-go-client/utils"
 	"os"
+	"sync"
 	"time"
 )
 
 var hostname, _ = os.Hostname()
 var pid = os.Getpid()
-var commit = utils.ReadGitCommit()
-var tag = utils.ReadGitTag()
+
+var buildInfo utils.BuildInfo
+var buildInfoOnce sync.Once
+var gitBuildInfo utils.BuildInfo
+var gitBuildInfoOnce sync.Once
 
 type Config struct {
 	Grpc       string
@@ -19,8 +24,56 @@ type Config struct {
 	PublicKey  string
 	PrivateKey string
 	Hostname   string
-	Version    string
+	Version    string // BuildInfo().ShortVersion() if empty
 	NoCipher   bool
+	Cipher     cipher.Mode // cipher.ModeGcm for authenticated encryption, ModeCfb if empty
+
+	// AsyncQueueSize enables async mode: records are queued and sent by a background goroutine.
+	AsyncQueueSize int
+	AsyncOverflow  Overflow
+
+	// BatchSize > 1 makes the gRPC transport send packages with PushBatch,
+	// a batch goes out when it is full or BatchWindow after its first package.
+	// The packages go one by one with Push to the servers without PushBatch.
+	BatchSize   int
+	BatchWindow time.Duration
+
+	// RetryAttempts is how many times a failed push is repeated. The delay
+	// starts at RetryBackoff and doubles up to RetryMaxBackoff, the same
+	// delays are used between reconnection attempts.
+	RetryAttempts   int
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+
+	// SpoolDir enables the on-disk spool: records which could not be sent
+	// are stored there and sent again when the collector is back. Every
+	// transport uses a subdirectory of its own, the directory must not be
+	// shared between processes.
+	SpoolDir         string
+	SpoolSegmentSize int64
+	SpoolMaxSize     int64
+
+	// Destinations are more logr servers every record is duplicated to.
+	Destinations []Destination
+
+	// Sinks get every record in addition to the logr server.
+	Sinks []Sink
+
+	// MaxLabelSets caps the label sets a counter key can have in a window,
+	// DEFAULT_MAX_LABEL_SETS if 0. Values with a new label set over
+	// the cap are counted with OVERFLOW_LABELS until the next window.
+	MaxLabelSets int
+
+	// CounterInterval is how often counters push their counts,
+	// DEFAULT_COUNTER_INTERVAL if 0. With CounterAlign the windows start on
+	// multiples of the interval, so the windows of many hosts line up.
+	// Counter.SetInterval changes them for a single counter.
+	CounterInterval time.Duration
+	CounterAlign    bool
+
+	// GitFallback lets BuildInfo run git when the binary has no VCS info,
+	// like with go run. It needs git and the sources at hand.
+	GitFallback bool
 }
 
 func (c *Config) NewLogger(logname string) (*Logger, error) {
@@ -33,6 +86,7 @@ func (c *Config) NewLogger(logname string) (*Logger, error) {
 		Console: true,
 		Levels:  Levels,
 	}
+	logger.Transport.name = logname
 	err := logger.Connect(c)
 	logger.Counter, _ = c.NewCounter(logname)
 	return logger, err
@@ -44,11 +98,13 @@ func (c *Config) NewCounter(name string) (*Counter, error) {
 		Logname: name,
 		State:   make(map[string]*types.Count),
 	}
+	counter.Transport.name = name + ".counts"
 	err := counter.Connect(c)
-	if err != nil {
-		return counter, err
+	interval := c.CounterInterval
+	if interval <= 0 {
+		interval = DEFAULT_COUNTER_INTERVAL
 	}
-	counter.run(10 * time.Second)
+	counter.run(interval, c.CounterAlign)
 	return counter, err
 }
 
@@ -81,14 +137,26 @@ func (c *Config) GetPid() int {
 	return pid
 }
 
+// BuildInfo is what is known about the build of the program. It is read
+// from the binary, and from git when GitFallback is set and the binary
+// has no revision in it.
+func (c *Config) BuildInfo() utils.BuildInfo {
+	buildInfoOnce.Do(func() {
+		buildInfo = utils.ReadBuildInfo()
+	})
+	res := buildInfo
+	if c.GitFallback && res.Revision == "" {
+		gitBuildInfoOnce.Do(func() {
+			gitBuildInfo = utils.ReadGitBuildInfo()
+		})
+		res.Revision, res.Tag = gitBuildInfo.Revision, gitBuildInfo.Tag
+	}
+	return res
+}
+
 func (c *Config) GetVersion() string {
 	if c.Version != "" {
 		return c.Version
-	} else if tag != "" {
-		return tag
-	} else if len(commit) >= 6 {
-		return commit[:6]
-	} else {
-		return ""
 	}
+	return c.BuildInfo().ShortVersion()
 }
@@ -0,0 +1,203 @@
+package logr_go_client
+
+import (
+	"errors"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ConnState int32
+
+const (
+	ConnDisconnected ConnState = iota
+	ConnConnecting
+	ConnConnected
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case ConnConnecting:
+		return "connecting"
+	case ConnConnected:
+		return "connected"
+	default:
+		return "disconnected"
+	}
+}
+
+var ErrNotConnected = errors.New("not connected")
+
+// connection owns the UDP socket or the gRPC client of a sink and replaces
+// the socket when it breaks, so the sink itself never has to.
+type connection struct {
+	sync.RWMutex
+	conf       *Config
+	network    string // "udp" or "grpc"
+	addr       string
+	udp        net.Conn
+	grpcConn   *grpc.ClientConn
+	grpcClient pb.LogRpcClient
+	state      int32
+	redialing  int32
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// dial connects to addr. On failure the returned connection keeps
+// redialing in the background until it succeeds or is closed.
+func dial(conf *Config, network string, addr string) (*connection, error) {
+	c := &connection{
+		conf:    conf,
+		network: network,
+		addr:    addr,
+		closed:  make(chan struct{}),
+	}
+	err := c.dial()
+	if err != nil {
+		c.redial()
+	}
+	return c, err
+}
+
+func (c *connection) dial() error {
+	atomic.StoreInt32(&c.state, int32(ConnConnecting))
+	var err error
+	if !c.isGrpc() {
+		var conn net.Conn
+		if conn, err = net.Dial("udp", c.addr); err == nil {
+			c.Lock()
+			prev := c.udp
+			c.udp = conn
+			c.Unlock()
+			if prev != nil {
+				prev.Close()
+			}
+		}
+	} else {
+		// grpc.ClientConn reconnects by itself, so it is created only once.
+		var conn *grpc.ClientConn
+		if conn, err = grpc.Dial(c.addr, grpc.WithTransportCredentials(insecure.NewCredentials())); err == nil {
+			c.Lock()
+			c.grpcConn = conn
+			c.grpcClient = pb.NewLogRpcClient(conn)
+			c.Unlock()
+		}
+	}
+	if err != nil {
+		atomic.StoreInt32(&c.state, int32(ConnDisconnected))
+		return err
+	}
+	atomic.StoreInt32(&c.state, int32(ConnConnected))
+	return nil
+}
+
+func (c *connection) redial() {
+	if !atomic.CompareAndSwapInt32(&c.redialing, 0, 1) {
+		return
+	}
+	atomic.StoreInt32(&c.state, int32(ConnDisconnected))
+	go func() {
+		defer atomic.StoreInt32(&c.redialing, 0)
+		for attempt := 0; ; attempt++ {
+			select {
+			case <-c.closed:
+				return
+			case <-time.After(c.conf.backoff(attempt)):
+			}
+			if c.dial() == nil {
+				select {
+				case <-c.closed:
+					c.close()
+				default:
+				}
+				return
+			}
+		}
+	}()
+}
+
+func (c *connection) connState() ConnState {
+	c.RLock()
+	grpcConn := c.grpcConn
+	c.RUnlock()
+	if grpcConn == nil {
+		return ConnState(atomic.LoadInt32(&c.state))
+	}
+	switch grpcConn.GetState() {
+	case connectivity.Ready:
+		return ConnConnected
+	case connectivity.Idle, connectivity.Connecting:
+		return ConnConnecting
+	default:
+		return ConnDisconnected
+	}
+}
+
+func (c *connection) isGrpc() bool {
+	return c.network == "grpc"
+}
+
+func (c *connection) client() (pb.LogRpcClient, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.grpcClient == nil {
+		return nil, ErrNotConnected
+	}
+	return c.grpcClient, nil
+}
+
+func (c *connection) udpConn() net.Conn {
+	c.RLock()
+	defer c.RUnlock()
+	return c.udp
+}
+
+func (c *connection) grpcClientConn() *grpc.ClientConn {
+	c.RLock()
+	defer c.RUnlock()
+	return c.grpcConn
+}
+
+func (c *connection) write(msg []byte) error {
+	c.RLock()
+	conn := c.udp
+	c.RUnlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	if _, err := conn.Write(msg); err != nil {
+		c.redial()
+		return err
+	}
+	return nil
+}
+
+// shutdown stops redialing and cuts short the retries, the socket stays usable.
+func (c *connection) shutdown() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+func (c *connection) close() (err error) {
+	c.shutdown()
+	c.Lock()
+	defer c.Unlock()
+	if c.grpcConn != nil {
+		err = c.grpcConn.Close()
+		c.grpcConn = nil
+		c.grpcClient = nil
+	}
+	if c.udp != nil {
+		err = c.udp.Close()
+		c.udp = nil
+	}
+	atomic.StoreInt32(&c.state, int32(ConnDisconnected))
+	return err
+}
@@ -0,0 +1,125 @@
+package logr_go_client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/504dev/logr-go-client/types"
+	"strings"
+)
+
+const (
+	TRACE_ID_FIELD = "trace_id"
+	SPAN_ID_FIELD  = "span_id"
+)
+
+var ErrBadTraceparent = errors.New("malformed traceparent")
+
+type fieldsKey struct{}
+type traceKey struct{}
+
+// NewContext returns a copy of ctx carrying fields on top of the fields ctx
+// already carries. The *Ctx logging methods attach them to every log.
+func NewContext(ctx context.Context, fields types.Fields) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, FieldsFromContext(ctx).With(fields))
+}
+
+func FieldsFromContext(ctx context.Context) types.Fields {
+	fields, _ := ctx.Value(fieldsKey{}).(types.Fields)
+	return fields
+}
+
+// TraceContext is the W3C Trace Context of a request, as in the traceparent
+// header: "00-<trace id>-<span id>-<flags>".
+type TraceContext struct {
+	TraceId string // 32 lowercase hex digits
+	SpanId  string // 16 lowercase hex digits
+	Flags   byte
+}
+
+// ParseTraceparent parses the value of a traceparent header. The fields after
+// the flags, which the future versions may add, are ignored.
+func ParseTraceparent(header string) (TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return TraceContext{}, ErrBadTraceparent
+	}
+	tc := TraceContext{TraceId: parts[1], SpanId: parts[2]}
+	if !isHex(tc.TraceId, 32) || !isHex(tc.SpanId, 16) || !isHex(parts[3], 2) || !tc.Valid() {
+		return TraceContext{}, ErrBadTraceparent
+	}
+	flags, _ := hex.DecodeString(parts[3])
+	tc.Flags = flags[0]
+	return tc, nil
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// NewTrace starts a sampled trace with random ids.
+func NewTrace() TraceContext {
+	return TraceContext{TraceId: randomHex(16), SpanId: randomHex(8), Flags: 1}
+}
+
+// Child returns the context of a span inside tc: same trace, new span id.
+func (tc TraceContext) Child() TraceContext {
+	tc.SpanId = randomHex(8)
+	return tc
+}
+
+func randomHex(size int) string {
+	for {
+		b := make([]byte, size)
+		rand.Read(b)
+		if id := hex.EncodeToString(b); strings.Trim(id, "0") != "" {
+			return id
+		}
+	}
+}
+
+// Valid reports whether the ids are set and not all zeros, which the spec forbids.
+func (tc TraceContext) Valid() bool {
+	return strings.Trim(tc.TraceId, "0") != "" && strings.Trim(tc.SpanId, "0") != ""
+}
+
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&1 == 1
+}
+
+// Traceparent formats tc as a version 00 traceparent header.
+func (tc TraceContext) Traceparent() string {
+	return "00-" + tc.TraceId + "-" + tc.SpanId + "-" + hex.EncodeToString([]byte{tc.Flags})
+}
+
+// NewTraceContext returns a copy of ctx carrying tc, the logs made with it
+// get the TRACE_ID_FIELD and SPAN_ID_FIELD fields.
+func NewTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceKey{}).(TraceContext)
+	return tc, ok
+}
+
+// contextFields are the fields and the trace ids ctx carries.
+func contextFields(ctx context.Context) types.Fields {
+	if ctx == nil {
+		return nil
+	}
+	fields := FieldsFromContext(ctx)
+	if tc, ok := TraceFromContext(ctx); ok {
+		fields = fields.Set(TRACE_ID_FIELD, tc.TraceId).Set(SPAN_ID_FIELD, tc.SpanId)
+	}
+	return fields
+}
@@ -1,6 +1,7 @@
 package logr_go_client
 
 import (
+	"context"
 	"fmt"
 	"github.com/504dev/logr-go-client/types"
 	gojson "github.com/goccy/go-json"
@@ -20,11 +21,12 @@ import (
 type Kind string
 
 const (
-	KIND_AVG Kind = "avg"
-	KIND_INC      = "inc"
-	KIND_MAX      = "max"
-	KIND_MIN      = "min"
-	KIND_PER      = "per"
+	KIND_AVG  Kind = "avg"
+	KIND_INC       = "inc"
+	KIND_MAX       = "max"
+	KIND_MIN       = "min"
+	KIND_PER       = "per"
+	KIND_HIST      = "hist"
 )
 
 func (k Kind) Validate() bool {
@@ -39,12 +41,25 @@ func (k Kind) Validate() bool {
 		return true
 	case "per":
 		return true
+	case "hist":
+		return true
 	}
 	return false
 }
 
 var ts = time.Now()
 
+// Labels are the dimensions of a counter: Inc("http.requests", 1, Labels{"code": "200"}).
+type Labels = types.Labels
+
+const DEFAULT_MAX_LABEL_SETS = 100
+
+const DEFAULT_COUNTER_INTERVAL = 10 * time.Second
+
+// OVERFLOW_LABELS are given to the values of a counter key with too many label sets.
+var OVERFLOW_LABELS = Labels{"_overflow": "true"}
+
+// State is keyed by the keyname and the labels, see types.Labels.Key.
 type State map[string]*types.Count
 
 func (cm State) String() string {
@@ -62,18 +77,73 @@ type Counter struct {
 	Logname      string
 	watchSystem  bool
 	watchProcess bool
+	pushes       sync.WaitGroup
+	labelSets    map[string]map[string]struct{} // label sets of the current window by keyname
+	overflowed   map[string]bool                // keynames reported over MaxLabelSets
+	incTotals    map[string]float64             // flushed incs by state key, for PrometheusHandler
+	runMu        sync.Mutex                     // guards the fields below
+	stop         chan struct{}
+	stopped      chan struct{}
+	closed       bool
+	closeOnce    sync.Once
 }
 
-func (co *Counter) run(interval time.Duration) {
+func (co *Counter) run(interval time.Duration, align bool) {
+	co.runMu.Lock()
+	defer co.runMu.Unlock()
+	if co.closed {
+		return
+	}
 	co.Ticker = time.NewTicker(interval)
+	co.stop = make(chan struct{})
+	co.stopped = make(chan struct{})
+	ticker, stop, stopped := co.Ticker, co.stop, co.stopped
 	go (func() {
+		defer close(stopped)
+		if align {
+			boundary := time.NewTimer(time.Until(time.Now().Truncate(interval).Add(interval)))
+			select {
+			case <-stop:
+				boundary.Stop()
+				return
+			case <-boundary.C:
+				ticker.Reset(interval)
+				co.Flush()
+			}
+		}
 		for {
-			<-co.Ticker.C
-			co.Flush()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				co.Flush()
+			}
 		}
 	})()
 }
 
+// stopRun stops the flushes and waits until the running one is over.
+func (co *Counter) stopRun() {
+	co.runMu.Lock()
+	defer co.runMu.Unlock()
+	if co.stop == nil {
+		return
+	}
+	co.Ticker.Stop()
+	close(co.stop)
+	<-co.stopped
+	co.stop = nil
+}
+
+// SetInterval restarts the flushes with another interval. With align the
+// windows start on multiples of the interval, i.e. on :00, :10, :20 with
+// 10 seconds, so the windows of many hosts line up.
+func (co *Counter) SetInterval(interval time.Duration, align bool) {
+	co.stopRun()
+	co.run(interval, align)
+}
+
+// Flush starts a new window and pushes the counts of the finished one in the background.
 func (co *Counter) Flush() State {
 	if co.watchSystem {
 		co.collectSystemInfo()
@@ -82,54 +152,155 @@ func (co *Counter) Flush() State {
 		co.collectProcessInfo()
 	}
 
+	tmp := co.swap()
+	co.pushes.Add(1)
+	go func() {
+		defer co.pushes.Done()
+		co.push(tmp)
+	}()
+
+	return tmp
+}
+
+func (co *Counter) swap() State {
 	co.Lock()
 	defer co.Unlock()
-
 	tmp := co.State
 	co.statePrev = tmp
 	co.State = make(State)
-
-	go func() {
-		for _, c := range tmp {
-			_, err := co.PushCount(c)
-			if err != nil {
-				log.Println(err)
+	co.labelSets = nil // the cap is per window
+	for key, c := range tmp {
+		c.RLock()
+		if c.Metrics.Inc != nil {
+			if co.incTotals == nil {
+				co.incTotals = map[string]float64{}
 			}
+			co.incTotals[key] += c.Metrics.Inc.Val
 		}
-	}()
-
+		c.RUnlock()
+	}
 	return tmp
 }
 
-func (co *Counter) Touch(key string) *types.Count {
-	res, _ := co.touchSafe(key)
+func (co *Counter) push(state State) {
+	for _, c := range state {
+		_, err := co.PushCount(c)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// Close stops the ticker, pushes the counts of the current window, waits
+// for the pushes of the previous flushes and closes the transport. The
+// watchers are not polled once more, so closing doesn't take their time.
+// If ctx is done before the counts are pushed, the transport is closed
+// anyway and ctx.Err() is returned.
+func (co *Counter) Close(ctx context.Context) error {
+	var err error
+	co.closeOnce.Do(func() {
+		co.stopRun()
+		co.runMu.Lock()
+		co.closed = true
+		co.runMu.Unlock()
+		co.pushes.Add(1)
+		go func() {
+			defer co.pushes.Done()
+			co.push(co.swap())
+		}()
+		err = co.wait(ctx)
+		if closeErr := co.Transport.Close(); err == nil {
+			err = closeErr
+		}
+	})
+	return err
+}
+
+// wait waits until the counts of the previous flushes are pushed.
+func (co *Counter) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		co.pushes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (co *Counter) Touch(key string, labels ...Labels) *types.Count {
+	res, _ := co.touchSafe(key, types.MergeLabels(labels...))
 	return res
 }
 
-func (co *Counter) touchSafe(key string) (c *types.Count, new bool) {
+func (co *Counter) touchSafe(key string, labels Labels) (c *types.Count, new bool) {
 	co.Lock()
 	defer co.Unlock()
-	if _, ok := co.State[key]; !ok {
-		co.State[key] = &types.Count{
+	stateKey := labels.Key(key)
+	if _, ok := co.State[stateKey]; !ok {
+		if len(labels) > 0 && !co.allowLabels(key, stateKey) {
+			labels = OVERFLOW_LABELS
+			stateKey = labels.Key(key)
+		}
+	}
+	if _, ok := co.State[stateKey]; !ok {
+		co.State[stateKey] = &types.Count{
 			DashId:   co.Config.DashId,
 			Hostname: co.GetHostname(),
 			Logname:  co.Logname,
 			Keyname:  key,
+			Labels:   labels,
 			Version:  co.GetVersion(),
 		}
 		new = true
 	}
-	return co.State[key], new
+	return co.State[stateKey], new
 }
 
-func (co *Counter) Inc(key string, num float64) *types.Count {
-	return co.Touch(key).Inc(num)
+// allowLabels remembers the label set of stateKey and reports whether key
+// has not got more than MaxLabelSets of them in the current window. Must be
+// called with co locked.
+func (co *Counter) allowLabels(key string, stateKey string) bool {
+	if co.labelSets == nil {
+		co.labelSets = map[string]map[string]struct{}{}
+	}
+	sets := co.labelSets[key]
+	if sets == nil {
+		sets = map[string]struct{}{}
+		co.labelSets[key] = sets
+	}
+	if _, ok := sets[stateKey]; ok {
+		return true
+	}
+	max := co.Config.MaxLabelSets
+	if max <= 0 {
+		max = DEFAULT_MAX_LABEL_SETS
+	}
+	if len(sets) >= max {
+		if !co.overflowed[key] {
+			if co.overflowed == nil {
+				co.overflowed = map[string]bool{}
+			}
+			co.overflowed[key] = true
+			log.Printf("counter %s has over %d label sets in a window, the rest is counted with %s", key, max, OVERFLOW_LABELS)
+		}
+		return false
+	}
+	sets[stateKey] = struct{}{}
+	return true
 }
 
-func (co *Counter) IncDiff(key string, num float64) *types.Count {
-	res := co.Touch(key)
+func (co *Counter) Inc(key string, num float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Inc(num)
+}
+
+func (co *Counter) IncDiff(key string, num float64, labels ...Labels) *types.Count {
+	res := co.Touch(key, labels...)
 	if res.Metrics.Inc == nil {
-		if prev := co.prevInc(key); prev != nil {
+		if prev := co.prevInc(res.Labels.Key(key)); prev != nil {
 			res.IncLast(prev.Last)
 		} else {
 			return res.IncLast(num)
@@ -139,42 +310,54 @@ func (co *Counter) IncDiff(key string, num float64) *types.Count {
 	return res.Inc(num).IncLast(num)
 }
 
-func (co *Counter) prevInc(key string) *types.Inc {
+func (co *Counter) prevInc(stateKey string) *types.Inc {
 	co.RLock()
 	defer co.RUnlock()
-	if co.statePrev != nil && co.statePrev[key] != nil && co.statePrev[key].Metrics.Inc != nil {
-		return co.statePrev[key].Metrics.Inc
+	if co.statePrev != nil && co.statePrev[stateKey] != nil && co.statePrev[stateKey].Metrics.Inc != nil {
+		return co.statePrev[stateKey].Metrics.Inc
 	}
 	return nil
 }
 
-func (co *Counter) Max(key string, num float64) *types.Count {
-	return co.Touch(key).Max(num)
+func (co *Counter) Max(key string, num float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Max(num)
 }
 
-func (co *Counter) Min(key string, num float64) *types.Count {
-	return co.Touch(key).Min(num)
+func (co *Counter) Min(key string, num float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Min(num)
 }
 
-func (co *Counter) Avg(key string, num float64) *types.Count {
-	return co.Touch(key).Avg(num)
+func (co *Counter) Avg(key string, num float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Avg(num)
 }
 
-func (co *Counter) prevAvg(key string) *types.Avg {
+func (co *Counter) prevAvg(stateKey string) *types.Avg {
 	co.RLock()
 	defer co.RUnlock()
-	if co.statePrev != nil && co.statePrev[key] != nil && co.statePrev[key].Metrics.Avg != nil {
-		return co.statePrev[key].Metrics.Avg
+	if co.statePrev != nil && co.statePrev[stateKey] != nil && co.statePrev[stateKey].Metrics.Avg != nil {
+		return co.statePrev[stateKey].Metrics.Avg
 	}
 	return nil
 }
 
-func (co *Counter) Per(key string, taken float64, total float64) *types.Count {
-	return co.Touch(key).Per(taken, total)
+func (co *Counter) Per(key string, taken float64, total float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Per(taken, total)
+}
+
+// Hist adds the value to a histogram of key, its quantiles are precise
+// within types.HIST_ACCURACY. NaN and infinite values are ignored.
+func (co *Counter) Hist(key string, num float64, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).Hist(num)
+}
+
+func (co *Counter) Time(key string, d time.Duration, labels ...Labels) func() time.Duration {
+	return co.Touch(key, labels...).Time(d)
 }
 
-func (co *Counter) Time(key string, d time.Duration) func() time.Duration {
-	return co.Touch(key).Time(d)
+// TimeElapsed records a time measured before the key was known, like the
+// duration of a request, which is counted by its route.
+func (co *Counter) TimeElapsed(key string, d time.Duration, elapsed time.Duration, labels ...Labels) *types.Count {
+	return co.Touch(key, labels...).TimeElapsed(d, elapsed)
 }
 
 func (co *Counter) Duration() func() time.Duration {
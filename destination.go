@@ -0,0 +1,69 @@
+package logr_go_client
+
+import (
+	"github.com/504dev/logr-go-client/types"
+	"regexp"
+)
+
+const DEFAULT_DESTINATION_QUEUE_SIZE = 10000
+
+// Destination is an additional logr server the records are duplicated to.
+type Destination struct {
+	Udp      string
+	Grpc     string
+	Level    types.Level // logs below Level are not sent there
+	NoCounts bool
+}
+
+// LevelSink passes on logs of Level and above, and counts unless NoCounts is set.
+type LevelSink struct {
+	Sink
+	Level    types.Level
+	NoCounts bool
+}
+
+func (ls LevelSink) PushLog(log *types.Log) (int, error) {
+	if ls.Level != "" && types.Level(log.Level).Weight() < ls.Level.Weight() {
+		return 0, nil
+	}
+	return ls.Sink.PushLog(log)
+}
+
+func (ls LevelSink) PushCount(count *types.Count) (int, error) {
+	if ls.NoCounts {
+		return 0, nil
+	}
+	return ls.Sink.PushCount(count)
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._@-]+`)
+
+// connectDestinations gives every destination a transport of its own: own
+// connection, retries, spool and async queue, so a dead destination only
+// fills its own queue and doesn't hold back the others. For the same reason
+// the queue of a destination never blocks, OverflowBlock means OverflowDropOldest.
+func (tp *Transport) connectDestinations(conf *Config) (MultiSink, error) {
+	var err error
+	res := make(MultiSink, 0, len(conf.Destinations))
+	for _, d := range conf.Destinations {
+		dc := *conf
+		dc.Udp, dc.Grpc = d.Udp, d.Grpc
+		dc.Sinks, dc.Destinations = nil, nil
+		if dc.AsyncQueueSize <= 0 {
+			dc.AsyncQueueSize = DEFAULT_DESTINATION_QUEUE_SIZE
+		}
+		if dc.AsyncOverflow == OverflowBlock {
+			dc.AsyncOverflow = OverflowDropOldest
+		}
+		addr := d.Udp
+		if addr == "" {
+			addr = d.Grpc
+		}
+		dt := &Transport{name: unsafePathChars.ReplaceAllString(tp.name+"@"+addr, "_")}
+		if dtErr := dt.Connect(&dc); err == nil {
+			err = dtErr
+		}
+		res = append(res, LevelSink{Sink: dt, Level: d.Level, NoCounts: d.NoCounts})
+	}
+	return res, err
+}
@@ -0,0 +1,130 @@
+package logr_go_client
+
+import (
+	"context"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync/atomic"
+	"time"
+)
+
+// GrpcSink sends packages to the logr server with the LogRpc service,
+// coalescing them into PushBatch calls when Config.BatchSize > 1.
+type GrpcSink struct {
+	*Config
+	conn  *connection
+	batch *batcher
+	// fail gets the batched records which could not be sent
+	fail func(items []queueItem, err error) error
+	// set once the server turned out not to implement PushBatch
+	unaryOnly int32
+}
+
+// NewGrpcSink dials conf.Grpc. If that fails the error is returned along with
+// the sink, which keeps reconnecting in the background.
+func NewGrpcSink(conf *Config) (*GrpcSink, error) {
+	conn, err := dial(conf, "grpc", conf.Grpc)
+	gs := &GrpcSink{Config: conf, conn: conn}
+	if conf.BatchSize > 1 {
+		gs.batch = newBatcher(conf.BatchSize, conf.BatchWindow, gs.pushBatch)
+		gs.batch.fail = func(items []queueItem, err error) error {
+			if gs.fail != nil {
+				return gs.fail(items, err)
+			}
+			return err
+		}
+	}
+	return gs, err
+}
+
+func (gs *GrpcSink) ConnState() ConnState {
+	return gs.conn.connState()
+}
+
+func (gs *GrpcSink) pushOne(ctx context.Context, req *pb.LogRpcPackage) error {
+	return gs.retry(ctx, gs.conn.closed, func() error {
+		client, err := gs.conn.client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err = client.Push(ctx, req)
+		return err
+	})
+}
+
+// pushBatch sends the packages with PushBatch, or one by one with Push to
+// the servers older than PushBatch.
+func (gs *GrpcSink) pushBatch(ctx context.Context, packages []*pb.LogRpcPackage) error {
+	if atomic.LoadInt32(&gs.unaryOnly) == 0 {
+		err := gs.pushBatchOnce(ctx, packages)
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+		atomic.StoreInt32(&gs.unaryOnly, 1)
+	}
+	for _, p := range packages {
+		if err := gs.pushOne(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gs *GrpcSink) pushBatchOnce(ctx context.Context, packages []*pb.LogRpcPackage) error {
+	req := &pb.LogRpcBatch{Packages: packages}
+	return gs.retry(ctx, gs.conn.closed, func() error {
+		client, err := gs.conn.client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err = client.PushBatch(ctx, req)
+		return err
+	})
+}
+
+// push sends the item, or adds it to the current batch if batched is set.
+func (gs *GrpcSink) push(item queueItem, batched bool) error {
+	lp, err := gs.pack(item)
+	if err != nil {
+		return err
+	}
+	//fmt.Println(string(lp.ProtoBytes()), len(lp.ProtoBytes()))
+	req := lp.Proto()
+	if batched && gs.batch != nil {
+		return gs.batch.add(req, item)
+	}
+	return gs.pushOne(context.Background(), req)
+}
+
+func (gs *GrpcSink) PushLog(log *types.Log) (int, error) {
+	return 1, gs.push(queueItem{log: log}, true)
+}
+
+func (gs *GrpcSink) PushCount(count *types.Count) (int, error) {
+	return 0, gs.push(queueItem{count: count}, true)
+}
+
+func (gs *GrpcSink) Flush(ctx context.Context) error {
+	if gs.batch == nil {
+		return nil
+	}
+	return gs.batch.flush(ctx)
+}
+
+func (gs *GrpcSink) shutdown() {
+	gs.conn.shutdown()
+}
+
+func (gs *GrpcSink) Close() error {
+	err := gs.Flush(context.Background())
+	if closeErr := gs.conn.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,218 @@
+package logr_go_client
+
+import (
+	"context"
+	"github.com/504dev/logr-go-client/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DEFAULT_GRPC_METRIC = "grpc"
+
+// GrpcInterceptor logs a line per RPC and counts the calls, their latency
+// and the rate of server-side failures by method, on the servers and on the
+// clients alike:
+//
+//	<Metric>.server.calls    inc, by method and code
+//	<Metric>.server.latency  time in milliseconds, by method
+//	<Metric>.server.errors   per of Unknown, DeadlineExceeded, Unimplemented,
+//	                         Internal, Unavailable and DataLoss, by method
+//
+// and <Metric>.client.* the same. The traceparent metadata is passed from the
+// context of the client to the context of the server.
+type GrpcInterceptor struct {
+	Logger *Logger
+	Metric string // prefix of the counter keys, DEFAULT_GRPC_METRIC if empty
+	// Level gives the level of the log line, GrpcCodeLevel by default
+	Level func(code codes.Code) types.Level
+	// Skip leaves out the methods it returns true for, GrpcSkipHealth by default
+	Skip func(fullMethod string) bool
+}
+
+func (lg *Logger) GrpcInterceptor() *GrpcInterceptor {
+	return &GrpcInterceptor{Logger: lg}
+}
+
+// GrpcSkipHealth skips the methods of the grpc.health.v1 health checks.
+func GrpcSkipHealth(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
+
+// GrpcCodeLevel is error for the codes hinting at a server fault, warn for
+// the other failures and info for OK.
+func GrpcCodeLevel(code codes.Code) types.Level {
+	switch {
+	case code == codes.OK:
+		return types.LevelInfo
+	case grpcServerFault(code):
+		return types.LevelError
+	default:
+		return types.LevelWarn
+	}
+}
+
+func grpcServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+func (gi *GrpcInterceptor) skip(fullMethod string) bool {
+	if gi.Skip != nil {
+		return gi.Skip(fullMethod)
+	}
+	return GrpcSkipHealth(fullMethod)
+}
+
+func (gi *GrpcInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if gi.skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		ctx = grpcIncomingTrace(ctx)
+		res, err := handler(ctx, req)
+		gi.record(ctx, "server", info.FullMethod, err, time.Since(start))
+		return res, err
+	}
+}
+
+func (gi *GrpcInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if gi.skip(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		ctx := grpcIncomingTrace(ss.Context())
+		err := handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+		gi.record(ctx, "server", info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+func (gi *GrpcInterceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if gi.skip(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		start := time.Now()
+		err := invoker(grpcOutgoingTrace(ctx), method, req, reply, cc, opts...)
+		gi.record(ctx, "client", method, err, time.Since(start))
+		return err
+	}
+}
+
+// StreamClientInterceptor records a stream when it ends: when RecvMsg fails,
+// with io.EOF for the streams ending well, or gets the single response of a
+// client streaming RPC.
+func (gi *GrpcInterceptor) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if gi.skip(method) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		start := time.Now()
+		cs, err := streamer(grpcOutgoingTrace(ctx), desc, cc, method, opts...)
+		if err != nil {
+			gi.record(ctx, "client", method, err, time.Since(start))
+			return nil, err
+		}
+		return &grpcClientStream{ClientStream: cs, serverStreams: desc.ServerStreams, done: func(err error) {
+			gi.record(ctx, "client", method, err, time.Since(start))
+		}}, nil
+	}
+}
+
+func (gi *GrpcInterceptor) record(ctx context.Context, side string, method string, err error, elapsed time.Duration) {
+	lg := gi.Logger
+	metric := gi.Metric
+	if metric == "" {
+		metric = DEFAULT_GRPC_METRIC
+	}
+	metric += "." + side
+	code := status.Code(err)
+	lg.Counter.Inc(metric+".calls", 1, Labels{"method": method, "code": code.String()})
+	lg.Counter.TimeElapsed(metric+".latency", time.Millisecond, elapsed, Labels{"method": method})
+	failed := 0.0
+	if grpcServerFault(code) {
+		failed = 1
+	}
+	lg.Counter.Per(metric+".errors", failed, 1, Labels{"method": method})
+
+	level := GrpcCodeLevel(code)
+	if gi.Level != nil {
+		level = gi.Level(code)
+	}
+	if !lg.enabled(level) {
+		return
+	}
+	kv := []interface{}{
+		"method", method,
+		"code", code.String(),
+		"duration_ms", float64(elapsed.Microseconds()) / 1000,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		kv = append(kv, "peer", p.Addr.String())
+	}
+	if err != nil {
+		kv = append(kv, "error", status.Convert(err).Message())
+	}
+	fields := lg.Fields.With(contextFields(ctx)).With(types.KV(kv...))
+	msg := "grpc " + side + " " + method + " " + code.String() + " " + elapsed.Round(time.Microsecond).String()
+	lg.emit(lg.newLog(level, msg, fields))
+}
+
+// grpcIncomingTrace puts the trace context of the traceparent metadata into ctx.
+func grpcIncomingTrace(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("traceparent") {
+		if tc, err := ParseTraceparent(header); err == nil {
+			return NewTraceContext(ctx, tc.Child())
+		}
+	}
+	return ctx
+}
+
+// grpcOutgoingTrace sends the trace context of ctx as the traceparent metadata.
+func grpcOutgoingTrace(ctx context.Context) context.Context {
+	tc, ok := TraceFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "traceparent", tc.Traceparent())
+}
+
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+type grpcClientStream struct {
+	grpc.ClientStream
+	serverStreams bool
+	once          sync.Once
+	done          func(err error)
+}
+
+func (s *grpcClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.once.Do(func() { s.done(nil) })
+	case err != nil, !s.serverStreams:
+		s.once.Do(func() { s.done(err) })
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package logr_go_client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/504dev/logr-go-client/types"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const DEFAULT_HTTP_METRIC = "http"
+
+// HttpMiddleware logs a line per request and counts the requests, their
+// latency and the rate of server errors by route:
+//
+//	<Metric>.requests  inc, by method, route and status
+//	<Metric>.latency   time in milliseconds, by route
+//	<Metric>.errors    per of the 5xx responses, by route
+//
+// A valid traceparent header of the request is put into its context, so the
+// *Ctx logs of the handler carry the trace ids.
+type HttpMiddleware struct {
+	Logger *Logger
+	Metric string // prefix of the counter keys, DEFAULT_HTTP_METRIC if empty
+	// Route names the route of a request, the ServeMux pattern or the path by
+	// default. The label sets of a counter window are capped with
+	// Config.MaxLabelSets, prefer routes like "/users/{id}" to the paths with
+	// ids in them.
+	Route func(r *http.Request) string
+	// Level gives the level of the access line, StatusLevel by default
+	Level func(status int) types.Level
+	// Skip leaves out the requests it returns true for, like health checks
+	Skip func(r *http.Request) bool
+}
+
+func (lg *Logger) HttpMiddleware() *HttpMiddleware {
+	return &HttpMiddleware{Logger: lg}
+}
+
+// HttpHandler wraps next with the default HttpMiddleware.
+func (lg *Logger) HttpHandler(next http.Handler) http.Handler {
+	return lg.HttpMiddleware().Handler(next)
+}
+
+// StatusLevel is error for 5xx, warn for 4xx and info for the rest.
+func StatusLevel(status int) types.Level {
+	switch {
+	case status >= 500:
+		return types.LevelError
+	case status >= 400:
+		return types.LevelWarn
+	default:
+		return types.LevelInfo
+	}
+}
+
+func (m *HttpMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.Skip != nil && m.Skip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		if tc, err := ParseTraceparent(r.Header.Get("traceparent")); err == nil {
+			r = r.WithContext(NewTraceContext(r.Context(), tc.Child()))
+		}
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		m.record(r, sw, time.Since(start))
+	})
+}
+
+func (m *HttpMiddleware) route(r *http.Request) string {
+	if m.Route != nil {
+		return m.Route(r)
+	}
+	if pattern := requestPattern(r); pattern != "" {
+		return pattern
+	}
+	return r.URL.Path
+}
+
+func (m *HttpMiddleware) record(r *http.Request, sw *statusWriter, elapsed time.Duration) {
+	lg := m.Logger
+	metric := m.Metric
+	if metric == "" {
+		metric = DEFAULT_HTTP_METRIC
+	}
+	route := m.route(r)
+	status := strconv.Itoa(sw.status)
+	lg.Counter.Inc(metric+".requests", 1, Labels{"method": r.Method, "route": route, "status": status})
+	lg.Counter.TimeElapsed(metric+".latency", time.Millisecond, elapsed, Labels{"route": route})
+	failed := 0.0
+	if sw.status >= 500 {
+		failed = 1
+	}
+	lg.Counter.Per(metric+".errors", failed, 1, Labels{"route": route})
+
+	level := StatusLevel(sw.status)
+	if m.Level != nil {
+		level = m.Level(sw.status)
+	}
+	if !lg.enabled(level) {
+		return
+	}
+	fields := lg.Fields.With(contextFields(r.Context())).With(types.KV(
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", sw.status,
+		"bytes", sw.bytes,
+		"duration_ms", float64(elapsed.Microseconds())/1000,
+		"remote_addr", r.RemoteAddr,
+	))
+	msg := fmt.Sprintf("%s %s %d %dB %s %s", r.Method, r.URL.Path, sw.status, sw.bytes, elapsed.Round(time.Microsecond), r.RemoteAddr)
+	lg.emit(lg.newLog(level, msg, fields))
+}
+
+// statusWriter remembers the status and the size of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	// the informational 1xx responses precede the actual one
+	if w.status == 0 && (status >= 200 || status == http.StatusSwitchingProtocols) {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("http.Hijacker is not implemented by the response writer")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the wrapped writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,10 @@
+//go:build go1.22
+
+package logr_go_client
+
+import "net/http"
+
+// requestPattern is the ServeMux pattern which matched r, "" before Go 1.22.
+func requestPattern(r *http.Request) string {
+	return r.Pattern
+}
@@ -0,0 +1,9 @@
+//go:build !go1.22
+
+package logr_go_client
+
+import "net/http"
+
+func requestPattern(r *http.Request) string {
+	return ""
+}
@@ -1,6 +1,7 @@
 package logr_go_client
 
 import (
+	"context"
 	"fmt"
 	"github.com/504dev/logr-go-client/types"
 	"github.com/504dev/logr-go-client/utils"
@@ -34,6 +35,8 @@ var Levels = levels{
 
 const MAX_MESSAGE_SIZE = 9000
 
+const DEFAULT_CLOSE_TIMEOUT = 10 * time.Second
+
 type Logger struct {
 	*Config
 	Transport
@@ -42,16 +45,35 @@ type Logger struct {
 	Prefix  string
 	Level   string
 	Console bool
+	Fields  types.Fields
+	// Repanic makes Recover panic again after logging, for the panics to crash the program
+	Repanic bool
 	*Counter
-	Levels levels
+	Levels     levels
+	callerSkip int
+}
+
+// Flush waits until the queued logs and counts are sent.
+func (lg *Logger) Flush(ctx context.Context) error {
+	if err := lg.Transport.Flush(ctx); err != nil {
+		return err
+	}
+	if err := lg.Counter.wait(ctx); err != nil {
+		return err
+	}
+	return lg.Counter.Transport.Flush(ctx)
 }
 
+// Close closes the transport of the logs and then the counter, which pushes
+// its last counts first, waiting for them up to DEFAULT_CLOSE_TIMEOUT.
 func (lg *Logger) Close() error {
 	err := lg.Transport.Close()
-	if err != nil {
-		return err
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_CLOSE_TIMEOUT)
+	defer cancel()
+	if counterErr := lg.Counter.Close(ctx); err == nil {
+		err = counterErr
 	}
-	return lg.Counter.Transport.Close()
+	return err
 }
 
 func (lg *Logger) Of(logname string) *Logger {
@@ -60,6 +82,21 @@ func (lg *Logger) Of(logname string) *Logger {
 	return &tmp
 }
 
+// With returns a child logger which attaches the given key/value pairs to every record.
+func (lg *Logger) With(kv ...interface{}) *Logger {
+	tmp := *lg
+	tmp.Fields = lg.Fields.With(types.KV(kv...))
+	return &tmp
+}
+
+// WithCallerSkip returns a logger which reports the caller n more frames up
+// the stack, for the functions wrapping the logging methods.
+func (lg *Logger) WithCallerSkip(n int) *Logger {
+	tmp := *lg
+	tmp.callerSkip += n
+	return &tmp
+}
+
 func (lg *Logger) DefaultWriter() *Writer {
 	return &Writer{
 		Logger: lg,
@@ -80,38 +117,39 @@ var colorNotice = color.New(color.FgHiGreen).SprintFunc()
 var colorInfo = color.New(color.FgGreen).SprintFunc()
 var colorDebug = color.New(color.FgBlue).SprintFunc()
 
-func (lg *Logger) prefix(level types.Level) string {
-	dt := time.Now().Format(time.RFC3339)
-	var colored string
+// ColorLevel returns the level in the color the console output gives it.
+func ColorLevel(level types.Level) string {
 	switch level {
 	case types.LevelEmerg:
 		fallthrough
 	case types.LevelAlert:
 		fallthrough
 	case types.LevelCrit:
-		colored = colorCrit(level)
+		return colorCrit(level)
 	case types.LevelError:
-		colored = colorError(level)
+		return colorError(level)
 	case types.LevelWarn:
-		colored = colorWarn(level)
+		return colorWarn(level)
 	case types.LevelNotice:
-		colored = colorNotice(level)
+		return colorNotice(level)
 	case types.LevelInfo:
-		colored = colorInfo(level)
+		return colorInfo(level)
 	case types.LevelDebug:
-		colored = colorDebug(level)
-	default:
-		colored = string(level)
+		return colorDebug(level)
 	}
+	return string(level)
+}
+
+func (lg *Logger) prefix(level types.Level) string {
+	dt := time.Now().Format(time.RFC3339)
 	res := lg.Prefix
 	res = strings.Replace(res, "{time}", dt, -1)
-	res = strings.Replace(res, "{level}", colored, -1)
+	res = strings.Replace(res, "{level}", ColorLevel(level), -1)
 	return res
 }
 
-func (lg *Logger) body(msg string) string {
+func (lg *Logger) body(msg string, initiator string, caller string) string {
 	res := lg.Body
-	initiator, caller := utils.Initiator()
 	res = strings.Replace(res, "{logname}", lg.Logname, -1)
 	res = strings.Replace(res, "{version}", lg.GetVersion(), -1)
 	res = strings.Replace(res, "{pid}", strconv.Itoa(lg.GetPid()), -1)
@@ -132,54 +170,165 @@ func format(vals ...interface{}) string {
 
 func (lg *Logger) InfoErr(err error, v ...interface{}) {
 	if err == nil {
-		lg.Log(types.LevelInfo, v...)
+		lg.log(context.Background(), types.LevelInfo, v...)
 	} else {
-		lg.Log(types.LevelError, v...)
+		lg.log(context.Background(), types.LevelError, v...)
 	}
 }
 
 func (lg *Logger) Emerg(v ...interface{}) {
-	lg.Log(types.LevelEmerg, v...)
+	lg.log(context.Background(), types.LevelEmerg, v...)
 }
 
 func (lg *Logger) Alert(v ...interface{}) {
-	lg.Log(types.LevelAlert, v...)
+	lg.log(context.Background(), types.LevelAlert, v...)
 }
 
 func (lg *Logger) Crit(v ...interface{}) {
-	lg.Log(types.LevelCrit, v...)
+	lg.log(context.Background(), types.LevelCrit, v...)
 }
 
 func (lg *Logger) Error(v ...interface{}) {
-	lg.Log(types.LevelError, v...)
+	lg.log(context.Background(), types.LevelError, v...)
 }
 
 func (lg *Logger) Warn(v ...interface{}) {
-	lg.Log(types.LevelWarn, v...)
+	lg.log(context.Background(), types.LevelWarn, v...)
 }
 
 func (lg *Logger) Notice(v ...interface{}) {
-	lg.Log(types.LevelNotice, v...)
+	lg.log(context.Background(), types.LevelNotice, v...)
 }
 
 func (lg *Logger) Info(v ...interface{}) {
-	lg.Log(types.LevelInfo, v...)
+	lg.log(context.Background(), types.LevelInfo, v...)
 }
 
 func (lg *Logger) Debug(v ...interface{}) {
-	lg.Log(types.LevelDebug, v...)
+	lg.log(context.Background(), types.LevelDebug, v...)
+}
+
+func (lg *Logger) Emergw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelEmerg, msg, kv...)
+}
+
+func (lg *Logger) Alertw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelAlert, msg, kv...)
+}
+
+func (lg *Logger) Critw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelCrit, msg, kv...)
+}
+
+func (lg *Logger) Errorw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelError, msg, kv...)
+}
+
+func (lg *Logger) Warnw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelWarn, msg, kv...)
+}
+
+func (lg *Logger) Noticew(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelNotice, msg, kv...)
+}
+
+func (lg *Logger) Infow(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelInfo, msg, kv...)
+}
+
+func (lg *Logger) Debugw(msg string, kv ...interface{}) {
+	lg.logw(context.Background(), types.LevelDebug, msg, kv...)
+}
+
+func (lg *Logger) enabled(level types.Level) bool {
+	return lg.Level == "" || level.Weight() >= types.Level(lg.Level).Weight()
 }
 
 func (lg *Logger) Log(level types.Level, v ...interface{}) {
-	if lg.Level != "" && level.Weight() < types.Level(lg.Level).Weight() {
+	lg.log(context.Background(), level, v...)
+}
+
+// Logw logs msg as is, without formatting, with the given key/value pairs attached.
+func (lg *Logger) Logw(level types.Level, msg string, kv ...interface{}) {
+	lg.logw(context.Background(), level, msg, kv...)
+}
+
+// LogCtx logs like Log, with the fields and the trace ids carried by ctx attached.
+func (lg *Logger) LogCtx(ctx context.Context, level types.Level, v ...interface{}) {
+	lg.log(ctx, level, v...)
+}
+
+func (lg *Logger) LogwCtx(ctx context.Context, level types.Level, msg string, kv ...interface{}) {
+	lg.logw(ctx, level, msg, kv...)
+}
+
+func (lg *Logger) EmergCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelEmerg, v...)
+}
+
+func (lg *Logger) AlertCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelAlert, v...)
+}
+
+func (lg *Logger) CritCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelCrit, v...)
+}
+
+func (lg *Logger) ErrorCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelError, v...)
+}
+
+func (lg *Logger) WarnCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelWarn, v...)
+}
+
+func (lg *Logger) NoticeCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelNotice, v...)
+}
+
+func (lg *Logger) InfoCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelInfo, v...)
+}
+
+func (lg *Logger) DebugCtx(ctx context.Context, v ...interface{}) {
+	lg.log(ctx, types.LevelDebug, v...)
+}
+
+// callerDepth is how far the caller of the logging methods is from write:
+// the user calls Info, Info calls log, log calls write.
+const callerDepth = 3
+
+func (lg *Logger) log(ctx context.Context, level types.Level, v ...interface{}) {
+	if !lg.enabled(level) {
 		return
 	}
-	prefix := lg.prefix(level)
-	body := lg.body(format(v...))
+	lg.write(level, format(v...), lg.Fields.With(contextFields(ctx)))
+}
+
+func (lg *Logger) logw(ctx context.Context, level types.Level, msg string, kv ...interface{}) {
+	if !lg.enabled(level) {
+		return
+	}
+	lg.write(level, msg, lg.Fields.With(contextFields(ctx)).With(types.KV(kv...)))
+}
+
+func (lg *Logger) write(level types.Level, msg string, fields types.Fields) {
+	initiator, caller := utils.Caller(callerDepth + lg.callerSkip)
+	log := lg.newLog(level, lg.body(msg, initiator, caller), fields)
+	log.Initiator = initiator
+	lg.emit(log)
+}
+
+func (lg *Logger) emit(log *types.Log) (int, error) {
+	level := types.Level(log.Level)
 	if lg.Console {
-		fmt.Fprintln(level.Std(), prefix+body)
+		line := lg.prefix(level) + log.Message
+		if len(log.Fields) > 0 {
+			line += " " + log.Fields.String()
+		}
+		fmt.Fprintln(level.Std(), line)
 	}
-	lg.writeLevel(level, body)
+	return lg.PushLog(log)
 }
 
 func (lg *Logger) blankLog() *types.Log {
@@ -193,10 +342,10 @@ func (lg *Logger) blankLog() *types.Log {
 	}
 }
 
-func (lg *Logger) writeLevel(level types.Level, msg string) (int, error) {
+func (lg *Logger) newLog(level types.Level, msg string, fields types.Fields) *types.Log {
 	log := lg.blankLog()
 	log.Level = string(level)
 	log.Message = msg
-
-	return lg.PushLog(log)
+	log.Fields = fields
+	return log
 }
@@ -0,0 +1,234 @@
+// Package logrtest helps to test code which logs through a logr Logger.
+//
+//	logger, rec := logrtest.NewLogger(t, "app.log")
+//	doWork(logger)
+//	rec.AssertLogged(t, types.LevelError, "connection refused")
+package logrtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/receiver"
+	"github.com/504dev/logr-go-client/types"
+	gojson "github.com/goccy/go-json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Recorder is a Sink keeping every log and count it gets. Records are made
+// into the messages UdpSink sends to the logr server and decoded back, so
+// what is recorded is what the server would see.
+type Recorder struct {
+	conf        *logr.Config
+	decoder     *receiver.Config
+	reassembler *receiver.Reassembler
+	mu          sync.Mutex
+	logs        []*types.Log
+	counts      []*types.Count
+}
+
+// NewRecorder creates a recorder using the keys and the cipher of conf,
+// add it to conf.Sinks to record what the loggers of conf push.
+func NewRecorder(conf *logr.Config) *Recorder {
+	return &Recorder{
+		conf:        conf,
+		decoder:     &receiver.Config{PrivateKey: conf.PrivateKey, Cipher: conf.Cipher},
+		reassembler: receiver.NewReassembler(conf.PrivateKey, time.Minute),
+	}
+}
+
+// NewLogger creates a logger which pushes to a recorder only. The logger is
+// closed when the test ends.
+func NewLogger(t testing.TB, logname string) (*logr.Logger, *Recorder) {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("logrtest: %v", err)
+	}
+	conf := &logr.Config{
+		PublicKey:  "logrtest",
+		PrivateKey: base64.StdEncoding.EncodeToString(key),
+	}
+	rec := NewRecorder(conf)
+	conf.Sinks = []logr.Sink{rec}
+	logger, err := conf.NewLogger(logname)
+	if err != nil {
+		t.Fatalf("logrtest: %v", err)
+	}
+	logger.Console = false
+	t.Cleanup(func() { logger.Close() })
+	return logger, rec
+}
+
+// Flush pushes the counters of the logger and waits until everything the
+// logger has pushed is recorded.
+func Flush(t testing.TB, logger *logr.Logger) {
+	t.Helper()
+	logger.Counter.Flush()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := logger.Flush(ctx); err != nil {
+		t.Fatalf("logrtest: flush: %v", err)
+	}
+}
+
+func (r *Recorder) PushLog(log *types.Log) (int, error) {
+	messages, err := r.conf.UdpLogMessages(log)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range messages {
+		received := &types.LogPackage{}
+		if err = gojson.Unmarshal(msg, received); err != nil {
+			return 0, err
+		}
+		joined, err := r.reassembler.Add(received)
+		if err != nil {
+			return 0, err
+		}
+		if joined != nil {
+			decoded, _, err := r.decoder.Decode(joined)
+			if err != nil {
+				return 0, err
+			}
+			r.mu.Lock()
+			r.logs = append(r.logs, decoded)
+			r.mu.Unlock()
+		}
+	}
+	return len(messages), nil
+}
+
+func (r *Recorder) PushCount(count *types.Count) (int, error) {
+	msg, err := r.conf.UdpCountMessage(count)
+	if err != nil {
+		return 0, err
+	}
+	received := &types.LogPackage{}
+	if err = gojson.Unmarshal(msg, received); err != nil {
+		return 0, err
+	}
+	_, decoded, err := r.decoder.Decode(received)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	r.counts = append(r.counts, decoded)
+	r.mu.Unlock()
+	return len(msg), nil
+}
+
+func (r *Recorder) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// Logs returns the recorded logs, oldest first.
+func (r *Recorder) Logs() []*types.Log {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*types.Log(nil), r.logs...)
+}
+
+// Counts returns the recorded counts, oldest first.
+func (r *Recorder) Counts() []*types.Count {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*types.Count(nil), r.counts...)
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs, r.counts = nil, nil
+}
+
+// Logged reports whether a log of the level containing substring was recorded.
+func (r *Recorder) Logged(level types.Level, substring string) bool {
+	for _, log := range r.Logs() {
+		if log.Level == string(level) && strings.Contains(log.Message, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recorder) AssertLogged(t testing.TB, level types.Level, substring string) bool {
+	t.Helper()
+	if r.Logged(level, substring) {
+		return true
+	}
+	t.Errorf("no %s log containing %q was recorded, got:\n%s", level, substring, r.dump())
+	return false
+}
+
+func (r *Recorder) AssertNotLogged(t testing.TB, level types.Level, substring string) bool {
+	t.Helper()
+	if !r.Logged(level, substring) {
+		return true
+	}
+	t.Errorf("a %s log containing %q was recorded", level, substring)
+	return false
+}
+
+func (r *Recorder) dump() string {
+	logs := r.Logs()
+	if len(logs) == 0 {
+		return "  nothing"
+	}
+	lines := make([]string, len(logs))
+	for i, log := range logs {
+		lines[i] = fmt.Sprintf("  %s %s", log.Level, log.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// metrics sums up the counts of key, of every label set unless labels are given.
+func (r *Recorder) metrics(key string, labels []types.Labels) types.Metrics {
+	total := types.Metrics{}
+	want := types.MergeLabels(labels...).String()
+	for _, count := range r.Counts() {
+		if count.Keyname == key && (len(labels) == 0 || count.Labels.String() == want) {
+			total.Merge(count.Metrics)
+		}
+	}
+	return total
+}
+
+// CounterValue returns the value of the counter key of the given kind summed
+// up over the recorded counts: incs and pers are added up, avg is the average
+// of all the values, max and min are the extremes, hist is the median. It is
+// 0 if nothing was recorded. Without labels all the label sets of key count.
+func (r *Recorder) CounterValue(key string, kind logr.Kind, labels ...types.Labels) float64 {
+	if kind == logr.KIND_HIST {
+		return r.CounterQuantile(key, 0.5, labels...)
+	}
+	value, ok := r.metrics(key, labels).ToMap()[string(kind)]
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
+
+// CounterQuantile returns the q-quantile of the recorded histograms of key.
+func (r *Recorder) CounterQuantile(key string, q float64, labels ...types.Labels) float64 {
+	total := r.metrics(key, labels)
+	if total.Hist == nil {
+		return 0
+	}
+	return total.Hist.Quantile(q)
+}
@@ -0,0 +1,286 @@
+package logr_go_client
+
+import (
+	"bufio"
+	"github.com/504dev/logr-go-client/types"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const PROMETHEUS_CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+
+var promUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+var promUnsafeLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+type promSample struct {
+	suffix string
+	labels types.Labels
+	value  float64
+}
+
+type promFamily struct {
+	kind    string // counter, gauge or summary
+	keyname string // of the counts the family is made of
+	samples []promSample
+}
+
+// promSet is what a scrape collects. Keynames which turn into the same name,
+// like a.b and a_b, can't share a family: the one collected first gets it, the
+// others are skipped and told of in the collisions comments.
+type promSet struct {
+	families   map[string]*promFamily
+	collisions map[string]bool
+}
+
+// promReservedLabels are set by the handler, user labels with these names get
+// the exported_ prefix.
+var promReservedLabels = map[string]bool{"logname": true, "quantile": true}
+
+// PrometheusHandler serves the counters in the Prometheus text format: incs
+// are counters summed up since the start, avg, min, max and per are gauges
+// of the last flushed window (or of the current one before the first flush),
+// times and hists are summaries. The logname and the labels of the counts
+// become labels, the user labels named logname or quantile become
+// exported_logname and exported_quantile.
+func PrometheusHandler(counters ...*Counter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		set := &promSet{families: map[string]*promFamily{}, collisions: map[string]bool{}}
+		for _, co := range counters {
+			co.promCollect(set)
+		}
+		w.Header().Set("Content-Type", PROMETHEUS_CONTENT_TYPE)
+		writePrometheus(w, set)
+	})
+}
+
+func (co *Counter) PrometheusHandler() http.Handler {
+	return PrometheusHandler(co)
+}
+
+func promName(keyname string) string {
+	name := promUnsafeChars.ReplaceAllString(keyname, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+func promLabelSet(labels types.Labels, logname string) types.Labels {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := types.Labels{"logname": logname}
+	for _, k := range keys {
+		name := promUnsafeLabelChars.ReplaceAllString(k, "_")
+		if name == "" || (name[0] >= '0' && name[0] <= '9') {
+			name = "_" + name
+		}
+		if promReservedLabels[name] || strings.HasPrefix(name, "__") {
+			name = "exported_" + name
+		}
+		for _, taken := res[name]; taken; _, taken = res[name] {
+			name = "exported_" + name
+		}
+		res[name] = labels[k]
+	}
+	return res
+}
+
+// snapshot copies the metrics of the current and the last flushed window by state key.
+func (co *Counter) snapshot() (current map[string]*types.Count, last map[string]*types.Count, incTotals map[string]float64) {
+	co.RLock()
+	defer co.RUnlock()
+	copyState := func(state State) map[string]*types.Count {
+		res := make(map[string]*types.Count, len(state))
+		for key, c := range state {
+			c.RLock()
+			cp := &types.Count{Logname: c.Logname, Keyname: c.Keyname, Labels: c.Labels}
+			cp.Metrics.Merge(c.Metrics)
+			c.RUnlock()
+			res[key] = cp
+		}
+		return res
+	}
+	incTotals = make(map[string]float64, len(co.incTotals))
+	for key, v := range co.incTotals {
+		incTotals[key] = v
+	}
+	return copyState(co.State), copyState(co.statePrev), incTotals
+}
+
+func (co *Counter) promCollect(set *promSet) {
+	current, last, incTotals := co.snapshot()
+	counts := make(map[string]*types.Count, len(current)+len(last))
+	for key, c := range last {
+		counts[key] = c
+	}
+	for key, c := range current {
+		counts[key] = c
+	}
+	// in order, so the same keyname wins a collision on every scrape
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		c := counts[key]
+		add := func(name string, kind string, samples ...promSample) {
+			family := set.families[name]
+			if family == nil {
+				family = &promFamily{kind: kind, keyname: c.Keyname}
+				set.families[name] = family
+			} else if family.keyname != c.Keyname {
+				set.collisions[strconv.Quote(c.Keyname)+" skipped, "+name+" is taken by "+strconv.Quote(family.keyname)] = true
+				return
+			}
+			family.samples = append(family.samples, samples...)
+		}
+		name := promName(c.Keyname)
+		labels := promLabelSet(c.Labels, c.Logname)
+
+		// gauges show the last complete window, the current one until there is none
+		m := types.Metrics{}
+		if cur := current[key]; cur != nil {
+			m = cur.Metrics
+		}
+		if prev := last[key]; prev != nil {
+			if prev.Metrics.Avg != nil {
+				m.Avg = prev.Metrics.Avg
+			}
+			if prev.Metrics.Min != nil {
+				m.Min = prev.Metrics.Min
+			}
+			if prev.Metrics.Max != nil {
+				m.Max = prev.Metrics.Max
+			}
+			if prev.Metrics.Per != nil {
+				m.Per = prev.Metrics.Per
+			}
+			if prev.Metrics.Time != nil {
+				m.Time = prev.Metrics.Time
+			}
+			if prev.Metrics.Hist != nil {
+				m.Hist = prev.Metrics.Hist
+			}
+		}
+
+		total, counted := incTotals[key]
+		if cur := current[key]; cur != nil && cur.Metrics.Inc != nil {
+			total += cur.Metrics.Inc.Val
+			counted = true
+		}
+		if counted {
+			add(name+"_total", "counter", promSample{labels: labels, value: total})
+		}
+
+		if m.Time != nil && m.Avg != nil {
+			// Time records the elapsed time as a fraction of the expected duration
+			unit := time.Duration(m.Time.Duration).Seconds()
+			samples := []promSample{
+				{suffix: "_sum", labels: labels, value: m.Avg.Sum * unit},
+				{suffix: "_count", labels: labels, value: float64(m.Avg.Num)},
+			}
+			if m.Min != nil {
+				samples = append(samples, promSample{labels: types.MergeLabels(labels, types.Labels{"quantile": "0"}), value: m.Min.Val * unit})
+			}
+			if m.Max != nil {
+				samples = append(samples, promSample{labels: types.MergeLabels(labels, types.Labels{"quantile": "1"}), value: m.Max.Val * unit})
+			}
+			add(name+"_seconds", "summary", samples...)
+		} else {
+			if m.Avg != nil {
+				add(name+"_avg", "gauge", promSample{labels: labels, value: m.Avg.Value()})
+			}
+			if m.Min != nil {
+				add(name+"_min", "gauge", promSample{labels: labels, value: m.Min.Val})
+			}
+			if m.Max != nil {
+				add(name+"_max", "gauge", promSample{labels: labels, value: m.Max.Val})
+			}
+		}
+		if m.Per != nil {
+			add(name+"_per", "gauge", promSample{labels: labels, value: m.Per.Value()})
+		}
+		if m.Hist != nil {
+			samples := []promSample{
+				{suffix: "_sum", labels: labels, value: m.Hist.Sum},
+				{suffix: "_count", labels: labels, value: float64(m.Hist.Num)},
+			}
+			for _, q := range []float64{0.5, 0.9, 0.95, 0.99} {
+				quantile := types.Labels{"quantile": strconv.FormatFloat(q, 'g', -1, 64)}
+				samples = append(samples, promSample{labels: types.MergeLabels(labels, quantile), value: m.Hist.Quantile(q)})
+			}
+			add(name, "summary", samples...)
+		}
+	}
+}
+
+func writePrometheus(w http.ResponseWriter, set *promSet) {
+	names := make([]string, 0, len(set.families))
+	for name := range set.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collisions := make([]string, 0, len(set.collisions))
+	for collision := range set.collisions {
+		collisions = append(collisions, collision)
+	}
+	sort.Strings(collisions)
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	for _, collision := range collisions {
+		bw.WriteString("# collision: " + collision + "\n")
+	}
+	for _, name := range names {
+		family := set.families[name]
+		lines := make([]string, len(family.samples))
+		for i, s := range family.samples {
+			lines[i] = name + s.suffix + promLabels(s.labels) + " " + promValue(s.value)
+		}
+		sort.Strings(lines)
+		bw.WriteString("# TYPE " + name + " " + family.kind + "\n")
+		for _, line := range lines {
+			bw.WriteString(line + "\n")
+		}
+	}
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promLabels(labels types.Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + `="` + promLabelEscaper.Replace(labels[k]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func promValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
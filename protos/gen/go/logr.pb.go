@@ -20,6 +20,53 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type LogRpcBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packages []*LogRpcPackage `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+}
+
+func (x *LogRpcBatch) Reset() {
+	*x = LogRpcBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logr_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRpcBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRpcBatch) ProtoMessage() {}
+
+func (x *LogRpcBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_logr_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRpcBatch.ProtoReflect.Descriptor instead.
+func (*LogRpcBatch) Descriptor() ([]byte, []int) {
+	return file_logr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LogRpcBatch) GetPackages() []*LogRpcPackage {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
 type LogRpcPackage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -39,7 +86,7 @@ type LogRpcPackage struct {
 func (x *LogRpcPackage) Reset() {
 	*x = LogRpcPackage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[0]
+		mi := &file_logr_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -52,7 +99,7 @@ func (x *LogRpcPackage) String() string {
 func (*LogRpcPackage) ProtoMessage() {}
 
 func (x *LogRpcPackage) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[0]
+	mi := &file_logr_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -65,7 +112,7 @@ func (x *LogRpcPackage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0}
+	return file_logr_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *LogRpcPackage) GetDashId() uint32 {
@@ -140,7 +187,7 @@ type Response struct {
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[1]
+		mi := &file_logr_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -153,7 +200,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[1]
+	mi := &file_logr_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,7 +213,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{1}
+	return file_logr_proto_rawDescGZIP(), []int{2}
 }
 
 type LogRpcPackage_Log struct {
@@ -174,21 +221,22 @@ type LogRpcPackage_Log struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DashId    uint32 `protobuf:"varint,1,opt,name=dash_id,json=dashId,proto3" json:"dash_id,omitempty"`
-	Timestamp int64  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Logname   string `protobuf:"bytes,3,opt,name=logname,proto3" json:"logname,omitempty"`
-	Hostname  string `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Version   string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
-	Level     string `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
-	Message   string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
-	Initiator string `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
-	Pid       uint32 `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
+	DashId    uint32                 `protobuf:"varint,1,opt,name=dash_id,json=dashId,proto3" json:"dash_id,omitempty"`
+	Timestamp int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Logname   string                 `protobuf:"bytes,3,opt,name=logname,proto3" json:"logname,omitempty"`
+	Hostname  string                 `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Version   string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Level     string                 `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
+	Message   string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Initiator string                 `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	Pid       uint32                 `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
+	Fields    []*LogRpcPackage_Field `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty"`
 }
 
 func (x *LogRpcPackage_Log) Reset() {
 	*x = LogRpcPackage_Log{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[2]
+		mi := &file_logr_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -201,7 +249,7 @@ func (x *LogRpcPackage_Log) String() string {
 func (*LogRpcPackage_Log) ProtoMessage() {}
 
 func (x *LogRpcPackage_Log) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[2]
+	mi := &file_logr_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,7 +262,7 @@ func (x *LogRpcPackage_Log) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Log.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Log) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 0}
+	return file_logr_proto_rawDescGZIP(), []int{1, 0}
 }
 
 func (x *LogRpcPackage_Log) GetDashId() uint32 {
@@ -280,6 +328,13 @@ func (x *LogRpcPackage_Log) GetPid() uint32 {
 	return 0
 }
 
+func (x *LogRpcPackage_Log) GetFields() []*LogRpcPackage_Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type LogRpcPackage_Count struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -297,12 +352,14 @@ type LogRpcPackage_Count struct {
 	Avg       *LogRpcPackage_Count_Avg  `protobuf:"bytes,10,opt,name=avg,proto3" json:"avg,omitempty"`
 	Per       *LogRpcPackage_Count_Per  `protobuf:"bytes,11,opt,name=per,proto3" json:"per,omitempty"`
 	Time      *LogRpcPackage_Count_Time `protobuf:"bytes,12,opt,name=time,proto3" json:"time,omitempty"`
+	Hist      *LogRpcPackage_Count_Hist `protobuf:"bytes,13,opt,name=hist,proto3" json:"hist,omitempty"`
+	Labels    map[string]string         `protobuf:"bytes,14,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *LogRpcPackage_Count) Reset() {
 	*x = LogRpcPackage_Count{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[3]
+		mi := &file_logr_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -315,7 +372,7 @@ func (x *LogRpcPackage_Count) String() string {
 func (*LogRpcPackage_Count) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[3]
+	mi := &file_logr_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +385,7 @@ func (x *LogRpcPackage_Count) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1}
 }
 
 func (x *LogRpcPackage_Count) GetDashId() uint32 {
@@ -415,6 +472,136 @@ func (x *LogRpcPackage_Count) GetTime() *LogRpcPackage_Count_Time {
 	return nil
 }
 
+func (x *LogRpcPackage_Count) GetHist() *LogRpcPackage_Count_Hist {
+	if x != nil {
+		return x.Hist
+	}
+	return nil
+}
+
+func (x *LogRpcPackage_Count) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type LogRpcPackage_Field struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Types that are assignable to Value:
+	//	*LogRpcPackage_Field_Str
+	//	*LogRpcPackage_Field_Num
+	//	*LogRpcPackage_Field_Int
+	//	*LogRpcPackage_Field_Bool
+	Value isLogRpcPackage_Field_Value `protobuf_oneof:"value"`
+}
+
+func (x *LogRpcPackage_Field) Reset() {
+	*x = LogRpcPackage_Field{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logr_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRpcPackage_Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRpcPackage_Field) ProtoMessage() {}
+
+func (x *LogRpcPackage_Field) ProtoReflect() protoreflect.Message {
+	mi := &file_logr_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRpcPackage_Field.ProtoReflect.Descriptor instead.
+func (*LogRpcPackage_Field) Descriptor() ([]byte, []int) {
+	return file_logr_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *LogRpcPackage_Field) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (m *LogRpcPackage_Field) GetValue() isLogRpcPackage_Field_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *LogRpcPackage_Field) GetStr() string {
+	if x, ok := x.GetValue().(*LogRpcPackage_Field_Str); ok {
+		return x.Str
+	}
+	return ""
+}
+
+func (x *LogRpcPackage_Field) GetNum() float64 {
+	if x, ok := x.GetValue().(*LogRpcPackage_Field_Num); ok {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *LogRpcPackage_Field) GetInt() int64 {
+	if x, ok := x.GetValue().(*LogRpcPackage_Field_Int); ok {
+		return x.Int
+	}
+	return 0
+}
+
+func (x *LogRpcPackage_Field) GetBool() bool {
+	if x, ok := x.GetValue().(*LogRpcPackage_Field_Bool); ok {
+		return x.Bool
+	}
+	return false
+}
+
+type isLogRpcPackage_Field_Value interface {
+	isLogRpcPackage_Field_Value()
+}
+
+type LogRpcPackage_Field_Str struct {
+	Str string `protobuf:"bytes,2,opt,name=str,proto3,oneof"`
+}
+
+type LogRpcPackage_Field_Num struct {
+	Num float64 `protobuf:"fixed64,3,opt,name=num,proto3,oneof"`
+}
+
+type LogRpcPackage_Field_Int struct {
+	Int int64 `protobuf:"varint,4,opt,name=int,proto3,oneof"`
+}
+
+type LogRpcPackage_Field_Bool struct {
+	Bool bool `protobuf:"varint,5,opt,name=bool,proto3,oneof"`
+}
+
+func (*LogRpcPackage_Field_Str) isLogRpcPackage_Field_Value() {}
+
+func (*LogRpcPackage_Field_Num) isLogRpcPackage_Field_Value() {}
+
+func (*LogRpcPackage_Field_Int) isLogRpcPackage_Field_Value() {}
+
+func (*LogRpcPackage_Field_Bool) isLogRpcPackage_Field_Value() {}
+
 type LogRpcPackage_Chunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -429,7 +616,7 @@ type LogRpcPackage_Chunk struct {
 func (x *LogRpcPackage_Chunk) Reset() {
 	*x = LogRpcPackage_Chunk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[4]
+		mi := &file_logr_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -442,7 +629,7 @@ func (x *LogRpcPackage_Chunk) String() string {
 func (*LogRpcPackage_Chunk) ProtoMessage() {}
 
 func (x *LogRpcPackage_Chunk) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[4]
+	mi := &file_logr_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +642,7 @@ func (x *LogRpcPackage_Chunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Chunk.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Chunk) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 2}
+	return file_logr_proto_rawDescGZIP(), []int{1, 3}
 }
 
 func (x *LogRpcPackage_Chunk) GetUid() string {
@@ -497,7 +684,7 @@ type LogRpcPackage_Count_Inc struct {
 func (x *LogRpcPackage_Count_Inc) Reset() {
 	*x = LogRpcPackage_Count_Inc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[5]
+		mi := &file_logr_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -510,7 +697,7 @@ func (x *LogRpcPackage_Count_Inc) String() string {
 func (*LogRpcPackage_Count_Inc) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Inc) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[5]
+	mi := &file_logr_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +710,7 @@ func (x *LogRpcPackage_Count_Inc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Inc.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Inc) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 0}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 1}
 }
 
 func (x *LogRpcPackage_Count_Inc) GetInc() float64 {
@@ -544,7 +731,7 @@ type LogRpcPackage_Count_Max struct {
 func (x *LogRpcPackage_Count_Max) Reset() {
 	*x = LogRpcPackage_Count_Max{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[6]
+		mi := &file_logr_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +744,7 @@ func (x *LogRpcPackage_Count_Max) String() string {
 func (*LogRpcPackage_Count_Max) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Max) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[6]
+	mi := &file_logr_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +757,7 @@ func (x *LogRpcPackage_Count_Max) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Max.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Max) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 1}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 2}
 }
 
 func (x *LogRpcPackage_Count_Max) GetMax() float64 {
@@ -591,7 +778,7 @@ type LogRpcPackage_Count_Min struct {
 func (x *LogRpcPackage_Count_Min) Reset() {
 	*x = LogRpcPackage_Count_Min{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[7]
+		mi := &file_logr_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -604,7 +791,7 @@ func (x *LogRpcPackage_Count_Min) String() string {
 func (*LogRpcPackage_Count_Min) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Min) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[7]
+	mi := &file_logr_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +804,7 @@ func (x *LogRpcPackage_Count_Min) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Min.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Min) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 2}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 3}
 }
 
 func (x *LogRpcPackage_Count_Min) GetMin() float64 {
@@ -639,7 +826,7 @@ type LogRpcPackage_Count_Avg struct {
 func (x *LogRpcPackage_Count_Avg) Reset() {
 	*x = LogRpcPackage_Count_Avg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[8]
+		mi := &file_logr_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -652,7 +839,7 @@ func (x *LogRpcPackage_Count_Avg) String() string {
 func (*LogRpcPackage_Count_Avg) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Avg) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[8]
+	mi := &file_logr_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -665,7 +852,7 @@ func (x *LogRpcPackage_Count_Avg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Avg.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Avg) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 3}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 4}
 }
 
 func (x *LogRpcPackage_Count_Avg) GetSum() float64 {
@@ -694,7 +881,7 @@ type LogRpcPackage_Count_Per struct {
 func (x *LogRpcPackage_Count_Per) Reset() {
 	*x = LogRpcPackage_Count_Per{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[9]
+		mi := &file_logr_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -707,7 +894,7 @@ func (x *LogRpcPackage_Count_Per) String() string {
 func (*LogRpcPackage_Count_Per) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Per) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[9]
+	mi := &file_logr_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,7 +907,7 @@ func (x *LogRpcPackage_Count_Per) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Per.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Per) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 4}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 5}
 }
 
 func (x *LogRpcPackage_Count_Per) GetTaken() float64 {
@@ -748,7 +935,7 @@ type LogRpcPackage_Count_Time struct {
 func (x *LogRpcPackage_Count_Time) Reset() {
 	*x = LogRpcPackage_Count_Time{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logr_proto_msgTypes[10]
+		mi := &file_logr_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -761,7 +948,7 @@ func (x *LogRpcPackage_Count_Time) String() string {
 func (*LogRpcPackage_Count_Time) ProtoMessage() {}
 
 func (x *LogRpcPackage_Count_Time) ProtoReflect() protoreflect.Message {
-	mi := &file_logr_proto_msgTypes[10]
+	mi := &file_logr_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +961,7 @@ func (x *LogRpcPackage_Count_Time) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRpcPackage_Count_Time.ProtoReflect.Descriptor instead.
 func (*LogRpcPackage_Count_Time) Descriptor() ([]byte, []int) {
-	return file_logr_proto_rawDescGZIP(), []int{0, 1, 5}
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 6}
 }
 
 func (x *LogRpcPackage_Count_Time) GetDuration() int64 {
@@ -784,11 +971,94 @@ func (x *LogRpcPackage_Count_Time) GetDuration() int64 {
 	return 0
 }
 
+type LogRpcPackage_Count_Hist struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pos  map[int32]uint64 `protobuf:"bytes,1,rep,name=pos,proto3" json:"pos,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Neg  map[int32]uint64 `protobuf:"bytes,2,rep,name=neg,proto3" json:"neg,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Zero uint64           `protobuf:"varint,3,opt,name=zero,proto3" json:"zero,omitempty"`
+	Sum  float64          `protobuf:"fixed64,4,opt,name=sum,proto3" json:"sum,omitempty"`
+	Num  uint64           `protobuf:"varint,5,opt,name=num,proto3" json:"num,omitempty"`
+}
+
+func (x *LogRpcPackage_Count_Hist) Reset() {
+	*x = LogRpcPackage_Count_Hist{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logr_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRpcPackage_Count_Hist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRpcPackage_Count_Hist) ProtoMessage() {}
+
+func (x *LogRpcPackage_Count_Hist) ProtoReflect() protoreflect.Message {
+	mi := &file_logr_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRpcPackage_Count_Hist.ProtoReflect.Descriptor instead.
+func (*LogRpcPackage_Count_Hist) Descriptor() ([]byte, []int) {
+	return file_logr_proto_rawDescGZIP(), []int{1, 1, 7}
+}
+
+func (x *LogRpcPackage_Count_Hist) GetPos() map[int32]uint64 {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+func (x *LogRpcPackage_Count_Hist) GetNeg() map[int32]uint64 {
+	if x != nil {
+		return x.Neg
+	}
+	return nil
+}
+
+func (x *LogRpcPackage_Count_Hist) GetZero() uint64 {
+	if x != nil {
+		return x.Zero
+	}
+	return 0
+}
+
+func (x *LogRpcPackage_Count_Hist) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *LogRpcPackage_Count_Hist) GetNum() uint64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
 var File_logr_proto protoreflect.FileDescriptor
 
 var file_logr_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6c, 0x6f,
-	0x67, 0x72, 0x22, 0x9c, 0x0a, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63,
+	0x67, 0x72, 0x22, 0x3e, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70,
+	0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x22, 0x9a, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63,
 	0x6b, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x64, 0x61, 0x73, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a,
 	0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
@@ -808,7 +1078,7 @@ var file_logr_proto_rawDesc = []byte{
 	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f,
 	0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x68, 0x75, 0x6e,
 	0x6b, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x67, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x67, 0x1a, 0xec, 0x01, 0x0a, 0x03, 0x4c,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x67, 0x1a, 0x9f, 0x02, 0x0a, 0x03, 0x4c,
 	0x6f, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0d, 0x52, 0x06, 0x64, 0x61, 0x73, 0x68, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
@@ -823,60 +1093,103 @@ var file_logr_proto_rawDesc = []byte{
 	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
 	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e,
 	0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x1a, 0x9e, 0x05, 0x0a, 0x05, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x64, 0x61, 0x73, 0x68, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f,
-	0x67, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65,
-	0x79, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x63,
-	0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x2f, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63,
-	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x4d, 0x61,
-	0x78, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x2f, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70,
-	0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x4d,
-	0x69, 0x6e, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x6f, 0x67, 0x72,
+	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0xf3, 0x08, 0x0a,
+	0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x73, 0x68, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x64, 0x61, 0x73, 0x68, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x6f, 0x67, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x6f, 0x67, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x6b, 0x65, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6b, 0x65, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x07,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
 	0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
-	0x41, 0x76, 0x67, 0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x2f, 0x0a, 0x03, 0x70, 0x65, 0x72, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67,
+	0x49, 0x6e, 0x63, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x2f, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67,
 	0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x2e, 0x50, 0x65, 0x72, 0x52, 0x03, 0x70, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c,
+	0x2e, 0x4d, 0x61, 0x78, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x2f, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f,
+	0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x2e, 0x4d, 0x69, 0x6e, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x61, 0x76,
+	0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c,
 	0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x1a, 0x17, 0x0a,
-	0x03, 0x49, 0x6e, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x1a, 0x17, 0x0a, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x10, 0x0a,
-	0x03, 0x6d, 0x61, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x1a,
-	0x17, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x1a, 0x29, 0x0a, 0x03, 0x41, 0x76, 0x67, 0x12,
-	0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x73, 0x75,
-	0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
-	0x6e, 0x75, 0x6d, 0x1a, 0x31, 0x0a, 0x03, 0x50, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61,
-	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x61, 0x6b, 0x65, 0x6e,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x1a, 0x22, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x45, 0x0a, 0x05, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x01, 0x69, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01,
-	0x6e, 0x22, 0x0a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x35, 0x0a,
-	0x06, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x12, 0x2b, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12,
-	0x13, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x1a, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x6b, 0x6f, 0x7a, 0x68, 0x75, 0x72, 0x6b, 0x69,
-	0x6e, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x3b, 0x6c, 0x6f, 0x67, 0x72,
-	0x70, 0x63, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x2e, 0x41, 0x76, 0x67, 0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x2f, 0x0a, 0x03, 0x70,
+	0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e,
+	0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x2e, 0x50, 0x65, 0x72, 0x52, 0x03, 0x70, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x6f, 0x67,
+	0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x32, 0x0a, 0x04, 0x68, 0x69, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x52, 0x04,
+	0x68, 0x69, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0e,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x17,
+	0x0a, 0x03, 0x49, 0x6e, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x1a, 0x17, 0x0a, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78,
+	0x1a, 0x17, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x1a, 0x29, 0x0a, 0x03, 0x41, 0x76, 0x67,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x73,
+	0x75, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x6e, 0x75, 0x6d, 0x1a, 0x31, 0x0a, 0x03, 0x50, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x61, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x61, 0x6b, 0x65,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x1a, 0x22, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xa4, 0x02, 0x0a, 0x04,
+	0x48, 0x69, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x03, 0x70, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x48, 0x69, 0x73,
+	0x74, 0x2e, 0x50, 0x6f, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x70, 0x6f, 0x73, 0x12,
+	0x39, 0x0a, 0x03, 0x6e, 0x65, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c,
+	0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x2e, 0x4e, 0x65, 0x67,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x6e, 0x65, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x65,
+	0x72, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x7a, 0x65, 0x72, 0x6f, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x73, 0x75, 0x6d,
+	0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6e,
+	0x75, 0x6d, 0x1a, 0x36, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x4e, 0x65,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x74, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a,
+	0x03, 0x73, 0x74, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73, 0x74,
+	0x72, 0x12, 0x12, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x03, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x62, 0x6f, 0x6f,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x42,
+	0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x45, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x74, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01,
+	0x69, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x22,
+	0x0a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x65, 0x0a, 0x06, 0x4c,
+	0x6f, 0x67, 0x52, 0x70, 0x63, 0x12, 0x2b, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x13, 0x2e,
+	0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x1a, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x11, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x70, 0x63, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x1a, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x6b, 0x6f, 0x7a, 0x68, 0x75, 0x72, 0x6b, 0x69, 0x6e, 0x2e,
+	0x6c, 0x6f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x76, 0x31, 0x3b, 0x6c, 0x6f, 0x67, 0x72, 0x70, 0x63,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -891,37 +1204,51 @@ func file_logr_proto_rawDescGZIP() []byte {
 	return file_logr_proto_rawDescData
 }
 
-var file_logr_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_logr_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_logr_proto_goTypes = []interface{}{
-	(*LogRpcPackage)(nil),            // 0: logr.LogRpcPackage
-	(*Response)(nil),                 // 1: logr.Response
-	(*LogRpcPackage_Log)(nil),        // 2: logr.LogRpcPackage.Log
-	(*LogRpcPackage_Count)(nil),      // 3: logr.LogRpcPackage.Count
-	(*LogRpcPackage_Chunk)(nil),      // 4: logr.LogRpcPackage.Chunk
-	(*LogRpcPackage_Count_Inc)(nil),  // 5: logr.LogRpcPackage.Count.Inc
-	(*LogRpcPackage_Count_Max)(nil),  // 6: logr.LogRpcPackage.Count.Max
-	(*LogRpcPackage_Count_Min)(nil),  // 7: logr.LogRpcPackage.Count.Min
-	(*LogRpcPackage_Count_Avg)(nil),  // 8: logr.LogRpcPackage.Count.Avg
-	(*LogRpcPackage_Count_Per)(nil),  // 9: logr.LogRpcPackage.Count.Per
-	(*LogRpcPackage_Count_Time)(nil), // 10: logr.LogRpcPackage.Count.Time
+	(*LogRpcBatch)(nil),              // 0: logr.LogRpcBatch
+	(*LogRpcPackage)(nil),            // 1: logr.LogRpcPackage
+	(*Response)(nil),                 // 2: logr.Response
+	(*LogRpcPackage_Log)(nil),        // 3: logr.LogRpcPackage.Log
+	(*LogRpcPackage_Count)(nil),      // 4: logr.LogRpcPackage.Count
+	(*LogRpcPackage_Field)(nil),      // 5: logr.LogRpcPackage.Field
+	(*LogRpcPackage_Chunk)(nil),      // 6: logr.LogRpcPackage.Chunk
+	nil,                              // 7: logr.LogRpcPackage.Count.LabelsEntry
+	(*LogRpcPackage_Count_Inc)(nil),  // 8: logr.LogRpcPackage.Count.Inc
+	(*LogRpcPackage_Count_Max)(nil),  // 9: logr.LogRpcPackage.Count.Max
+	(*LogRpcPackage_Count_Min)(nil),  // 10: logr.LogRpcPackage.Count.Min
+	(*LogRpcPackage_Count_Avg)(nil),  // 11: logr.LogRpcPackage.Count.Avg
+	(*LogRpcPackage_Count_Per)(nil),  // 12: logr.LogRpcPackage.Count.Per
+	(*LogRpcPackage_Count_Time)(nil), // 13: logr.LogRpcPackage.Count.Time
+	(*LogRpcPackage_Count_Hist)(nil), // 14: logr.LogRpcPackage.Count.Hist
+	nil,                              // 15: logr.LogRpcPackage.Count.Hist.PosEntry
+	nil,                              // 16: logr.LogRpcPackage.Count.Hist.NegEntry
 }
 var file_logr_proto_depIdxs = []int32{
-	2,  // 0: logr.LogRpcPackage.log:type_name -> logr.LogRpcPackage.Log
-	3,  // 1: logr.LogRpcPackage.count:type_name -> logr.LogRpcPackage.Count
-	4,  // 2: logr.LogRpcPackage.chunk:type_name -> logr.LogRpcPackage.Chunk
-	5,  // 3: logr.LogRpcPackage.Count.inc:type_name -> logr.LogRpcPackage.Count.Inc
-	6,  // 4: logr.LogRpcPackage.Count.max:type_name -> logr.LogRpcPackage.Count.Max
-	7,  // 5: logr.LogRpcPackage.Count.min:type_name -> logr.LogRpcPackage.Count.Min
-	8,  // 6: logr.LogRpcPackage.Count.avg:type_name -> logr.LogRpcPackage.Count.Avg
-	9,  // 7: logr.LogRpcPackage.Count.per:type_name -> logr.LogRpcPackage.Count.Per
-	10, // 8: logr.LogRpcPackage.Count.time:type_name -> logr.LogRpcPackage.Count.Time
-	0,  // 9: logr.LogRpc.Push:input_type -> logr.LogRpcPackage
-	1,  // 10: logr.LogRpc.Push:output_type -> logr.Response
-	10, // [10:11] is the sub-list for method output_type
-	9,  // [9:10] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	1,  // 0: logr.LogRpcBatch.packages:type_name -> logr.LogRpcPackage
+	3,  // 1: logr.LogRpcPackage.log:type_name -> logr.LogRpcPackage.Log
+	4,  // 2: logr.LogRpcPackage.count:type_name -> logr.LogRpcPackage.Count
+	6,  // 3: logr.LogRpcPackage.chunk:type_name -> logr.LogRpcPackage.Chunk
+	5,  // 4: logr.LogRpcPackage.Log.fields:type_name -> logr.LogRpcPackage.Field
+	8,  // 5: logr.LogRpcPackage.Count.inc:type_name -> logr.LogRpcPackage.Count.Inc
+	9,  // 6: logr.LogRpcPackage.Count.max:type_name -> logr.LogRpcPackage.Count.Max
+	10, // 7: logr.LogRpcPackage.Count.min:type_name -> logr.LogRpcPackage.Count.Min
+	11, // 8: logr.LogRpcPackage.Count.avg:type_name -> logr.LogRpcPackage.Count.Avg
+	12, // 9: logr.LogRpcPackage.Count.per:type_name -> logr.LogRpcPackage.Count.Per
+	13, // 10: logr.LogRpcPackage.Count.time:type_name -> logr.LogRpcPackage.Count.Time
+	14, // 11: logr.LogRpcPackage.Count.hist:type_name -> logr.LogRpcPackage.Count.Hist
+	7,  // 12: logr.LogRpcPackage.Count.labels:type_name -> logr.LogRpcPackage.Count.LabelsEntry
+	15, // 13: logr.LogRpcPackage.Count.Hist.pos:type_name -> logr.LogRpcPackage.Count.Hist.PosEntry
+	16, // 14: logr.LogRpcPackage.Count.Hist.neg:type_name -> logr.LogRpcPackage.Count.Hist.NegEntry
+	1,  // 15: logr.LogRpc.Push:input_type -> logr.LogRpcPackage
+	0,  // 16: logr.LogRpc.PushBatch:input_type -> logr.LogRpcBatch
+	2,  // 17: logr.LogRpc.Push:output_type -> logr.Response
+	2,  // 18: logr.LogRpc.PushBatch:output_type -> logr.Response
+	17, // [17:19] is the sub-list for method output_type
+	15, // [15:17] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_logr_proto_init() }
@@ -931,7 +1258,7 @@ func file_logr_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_logr_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogRpcPackage); i {
+			switch v := v.(*LogRpcBatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -943,7 +1270,7 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*LogRpcPackage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -955,7 +1282,7 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogRpcPackage_Log); i {
+			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -967,7 +1294,7 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogRpcPackage_Count); i {
+			switch v := v.(*LogRpcPackage_Log); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -979,7 +1306,7 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogRpcPackage_Chunk); i {
+			switch v := v.(*LogRpcPackage_Count); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -991,7 +1318,7 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogRpcPackage_Count_Inc); i {
+			switch v := v.(*LogRpcPackage_Field); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1003,6 +1330,30 @@ func file_logr_proto_init() {
 			}
 		}
 		file_logr_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRpcPackage_Chunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logr_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRpcPackage_Count_Inc); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logr_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogRpcPackage_Count_Max); i {
 			case 0:
 				return &v.state
@@ -1014,7 +1365,7 @@ func file_logr_proto_init() {
 				return nil
 			}
 		}
-		file_logr_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_logr_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogRpcPackage_Count_Min); i {
 			case 0:
 				return &v.state
@@ -1026,7 +1377,7 @@ func file_logr_proto_init() {
 				return nil
 			}
 		}
-		file_logr_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_logr_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogRpcPackage_Count_Avg); i {
 			case 0:
 				return &v.state
@@ -1038,7 +1389,7 @@ func file_logr_proto_init() {
 				return nil
 			}
 		}
-		file_logr_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_logr_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogRpcPackage_Count_Per); i {
 			case 0:
 				return &v.state
@@ -1050,7 +1401,7 @@ func file_logr_proto_init() {
 				return nil
 			}
 		}
-		file_logr_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_logr_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LogRpcPackage_Count_Time); i {
 			case 0:
 				return &v.state
@@ -1062,6 +1413,24 @@ func file_logr_proto_init() {
 				return nil
 			}
 		}
+		file_logr_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRpcPackage_Count_Hist); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_logr_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*LogRpcPackage_Field_Str)(nil),
+		(*LogRpcPackage_Field_Num)(nil),
+		(*LogRpcPackage_Field_Int)(nil),
+		(*LogRpcPackage_Field_Bool)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1069,7 +1438,7 @@ func file_logr_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_logr_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LogRpcClient interface {
 	Push(ctx context.Context, in *LogRpcPackage, opts ...grpc.CallOption) (*Response, error)
+	PushBatch(ctx context.Context, in *LogRpcBatch, opts ...grpc.CallOption) (*Response, error)
 }
 
 type logRpcClient struct {
@@ -42,11 +43,21 @@ func (c *logRpcClient) Push(ctx context.Context, in *LogRpcPackage, opts ...grpc
 	return out, nil
 }
 
+func (c *logRpcClient) PushBatch(ctx context.Context, in *LogRpcBatch, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/logr.LogRpc/PushBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogRpcServer is the server API for LogRpc service.
 // All implementations must embed UnimplementedLogRpcServer
 // for forward compatibility
 type LogRpcServer interface {
 	Push(context.Context, *LogRpcPackage) (*Response, error)
+	PushBatch(context.Context, *LogRpcBatch) (*Response, error)
 	mustEmbedUnimplementedLogRpcServer()
 }
 
@@ -57,6 +68,9 @@ type UnimplementedLogRpcServer struct {
 func (UnimplementedLogRpcServer) Push(context.Context, *LogRpcPackage) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
 }
+func (UnimplementedLogRpcServer) PushBatch(context.Context, *LogRpcBatch) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushBatch not implemented")
+}
 func (UnimplementedLogRpcServer) mustEmbedUnimplementedLogRpcServer() {}
 
 // UnsafeLogRpcServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +102,24 @@ func _LogRpc_Push_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogRpc_PushBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogRpcBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogRpcServer).PushBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/logr.LogRpc/PushBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogRpcServer).PushBatch(ctx, req.(*LogRpcBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LogRpc_ServiceDesc is the grpc.ServiceDesc for LogRpc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +131,10 @@ var LogRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Push",
 			Handler:    _LogRpc_Push_Handler,
 		},
+		{
+			MethodName: "PushBatch",
+			Handler:    _LogRpc_PushBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "logr.proto",
@@ -0,0 +1,115 @@
+package receiver
+
+import (
+	"errors"
+	"github.com/504dev/logr-go-client/types"
+	"sync"
+	"time"
+)
+
+const DEFAULT_CHUNK_TIMEOUT = 5 * time.Second
+
+// MAX_CHUNKS bounds the memory a single forged package can claim.
+const MAX_CHUNKS = 1000
+
+var ErrBadSignature = errors.New("bad chunk signature")
+var ErrBadChunk = errors.New("bad chunk info")
+var ErrIncomplete = errors.New("chunks are missing, package dropped")
+
+type chunkGroup struct {
+	chunks   types.LogPackageChunks
+	received int
+	started  time.Time
+}
+
+// Reassembler collects the chunks made by LogPackage.Chunkify, in any
+// order, and joins them when the last one arrives. Groups which are not
+// complete within the timeout are dropped by Expire.
+type Reassembler struct {
+	privateKey string
+	timeout    time.Duration
+	mu         sync.Mutex
+	groups     map[string]*chunkGroup
+}
+
+func NewReassembler(privateKey string, timeout time.Duration) *Reassembler {
+	if timeout <= 0 {
+		timeout = DEFAULT_CHUNK_TIMEOUT
+	}
+	return &Reassembler{
+		privateKey: privateKey,
+		timeout:    timeout,
+		groups:     map[string]*chunkGroup{},
+	}
+}
+
+// Add verifies the signature of the chunk, unless there is no private key,
+// and returns the joined package once all of its chunks are there, nil
+// otherwise. Packages without chunk info (counts are never chunked) are
+// returned as they are.
+func (r *Reassembler) Add(lp *types.LogPackage) (*types.LogPackage, error) {
+	ch := lp.Chunk
+	if ch == nil {
+		return lp, nil
+	}
+	if ch.N <= 0 || ch.N > MAX_CHUNKS || ch.I < 0 || ch.I >= ch.N {
+		return nil, ErrBadChunk
+	}
+	if r.privateKey != "" {
+		sig, err := ch.CalcSig(r.privateKey)
+		if err != nil {
+			return nil, err
+		}
+		if sig != lp.Sig {
+			return nil, ErrBadSignature
+		}
+	}
+	if ch.N == 1 {
+		return lp, nil
+	}
+
+	key := lp.PublicKey + "|" + ch.Uid
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	group := r.groups[key]
+	if group == nil {
+		group = &chunkGroup{chunks: make(types.LogPackageChunks, ch.N), started: time.Now()}
+		r.groups[key] = group
+	}
+	if len(group.chunks) != ch.N {
+		return nil, ErrBadChunk
+	}
+	if group.chunks[ch.I] != nil {
+		return nil, nil // duplicate
+	}
+	group.chunks[ch.I] = lp
+	group.received++
+	if group.received < ch.N {
+		return nil, nil
+	}
+	delete(r.groups, key)
+	_, joined := group.chunks.Joined()
+	return joined, nil
+}
+
+// Expire drops the groups which have been waiting longer than the timeout
+// and returns how many were dropped.
+func (r *Reassembler) Expire(now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for key, group := range r.groups {
+		if now.Sub(group.started) > r.timeout {
+			delete(r.groups, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Pending returns the number of incomplete groups.
+func (r *Reassembler) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.groups)
+}
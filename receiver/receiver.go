@@ -0,0 +1,230 @@
+package receiver
+
+import (
+	"errors"
+	"github.com/504dev/logr-go-client/cipher"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/types"
+	gojson "github.com/goccy/go-json"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DEFAULT_BUFFER_SIZE = 1000
+
+const maxDatagramSize = 65536
+
+// readErrorPause keeps a socket failing over and over from spinning the read loop.
+const readErrorPause = 10 * time.Millisecond
+
+var ErrBufferFull = errors.New("receiver buffer is full, record dropped")
+var ErrNoKey = errors.New("encrypted package, but no private key to decrypt it")
+
+type Config struct {
+	PrivateKey   string
+	Cipher       cipher.Mode   // of the clients, both AES-CFB and AES-GCM are accepted if empty
+	ChunkTimeout time.Duration // incomplete chunked logs are dropped after it
+	BufferSize   int           // capacity of the Logs, Counts and Errors channels
+	// PlainOnly refuses the encrypted packages with ErrNoKey, for a receiver
+	// without the key which reads the packages of NoCipher clients only
+	PlainOnly bool
+}
+
+// Receiver reads the datagrams sent by UdpSink, reassembles chunked logs,
+// verifies and decrypts them, and emits the results on Logs and Counts.
+// None of the channels is waited on: a record which finds its channel full
+// is dropped and counted by Dropped, so a consumer may read only Logs or
+// only Counts. Errors get what could not be read or decoded.
+type Receiver struct {
+	*Config
+	Logs        chan *types.Log
+	Counts      chan *types.Count
+	Errors      chan error
+	conn        net.PacketConn
+	reassembler *Reassembler
+	expired     uint64
+	dropped     uint64
+	done        chan struct{}
+	wg          sync.WaitGroup
+	closeOnce   sync.Once
+}
+
+// Listen opens a UDP socket on addr and starts receiving.
+func (c *Config) Listen(addr string) (*Receiver, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return c.Serve(conn), nil
+}
+
+// Serve starts receiving on conn, the receiver takes over closing it.
+func (c *Config) Serve(conn net.PacketConn) *Receiver {
+	size := c.BufferSize
+	if size <= 0 {
+		size = DEFAULT_BUFFER_SIZE
+	}
+	r := &Receiver{
+		Config:      c,
+		Logs:        make(chan *types.Log, size),
+		Counts:      make(chan *types.Count, size),
+		Errors:      make(chan error, size),
+		conn:        conn,
+		reassembler: NewReassembler(c.PrivateKey, c.ChunkTimeout),
+		done:        make(chan struct{}),
+	}
+	r.wg.Add(2)
+	go r.read()
+	go r.expire()
+	return r
+}
+
+func (r *Receiver) Addr() net.Addr {
+	return r.conn.LocalAddr()
+}
+
+// Expired returns the number of chunked logs dropped because of missing chunks.
+func (r *Receiver) Expired() uint64 {
+	return atomic.LoadUint64(&r.expired)
+}
+
+// Dropped returns the number of logs and counts dropped because their channel was full.
+func (r *Receiver) Dropped() uint64 {
+	return atomic.LoadUint64(&r.dropped)
+}
+
+func (r *Receiver) read() {
+	defer r.wg.Done()
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-r.done:
+				return
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			r.error(err)
+			select {
+			case <-r.done:
+				return
+			case <-time.After(readErrorPause):
+			}
+			continue
+		}
+		lp := types.LogPackage{}
+		if err = gojson.Unmarshal(buf[:n], &lp); err != nil {
+			r.error(err)
+			continue
+		}
+		joined, err := r.reassembler.Add(&lp)
+		if err != nil {
+			r.error(err)
+			continue
+		}
+		if joined != nil {
+			r.emit(joined)
+		}
+	}
+}
+
+func (r *Receiver) expire() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.reassembler.timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case now := <-ticker.C:
+			if n := r.reassembler.Expire(now); n > 0 {
+				atomic.AddUint64(&r.expired, uint64(n))
+				r.error(ErrIncomplete)
+			}
+		}
+	}
+}
+
+func (r *Receiver) emit(lp *types.LogPackage) {
+	log, count, err := r.Decode(lp)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	if log != nil {
+		select {
+		case r.Logs <- log:
+		default:
+			r.drop()
+		}
+	}
+	if count != nil {
+		select {
+		case r.Counts <- count:
+		default:
+			r.drop()
+		}
+	}
+}
+
+func (r *Receiver) drop() {
+	atomic.AddUint64(&r.dropped, 1)
+	r.error(ErrBufferFull)
+}
+
+func (r *Receiver) error(err error) {
+	select {
+	case r.Errors <- err:
+	default:
+	}
+}
+
+// Close stops receiving and closes the socket and the channels.
+func (r *Receiver) Close() error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.done)
+		err = r.conn.Close()
+		r.wg.Wait()
+		close(r.Logs)
+		close(r.Counts)
+		close(r.Errors)
+	})
+	return err
+}
+
+// Decode decrypts or deserializes a complete package, whether it came over
+// UDP or gRPC. Either of the results may be nil.
+func (c *Config) Decode(lp *types.LogPackage) (*types.Log, *types.Count, error) {
+	if c.PlainOnly && (len(lp.CipherLog) > 0 || len(lp.CipherCount) > 0) {
+		return nil, nil, ErrNoKey
+	}
+	switch {
+	case len(lp.CipherLog) > 0:
+		if err := lp.DecryptLogMode(c.PrivateKey, c.Cipher); err != nil {
+			return nil, nil, err
+		}
+	case len(lp.PlainLog) > 0:
+		if err := lp.DeserializeLog(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(lp.CipherCount) > 0 {
+		if err := lp.DecryptCountMode(c.PrivateKey, c.Cipher); err != nil {
+			return nil, nil, err
+		}
+	}
+	return lp.Log, lp.Count, nil
+}
+
+// DecodeProto is Decode for the packages of the LogRpc service.
+func (c *Config) DecodeProto(p *pb.LogRpcPackage) (*types.Log, *types.Count, error) {
+	lp := types.LogPackage{}
+	lp.FromProto(p)
+	return c.Decode(&lp)
+}
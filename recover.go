@@ -0,0 +1,55 @@
+package logr_go_client
+
+import (
+	"context"
+	"fmt"
+	"github.com/504dev/logr-go-client/types"
+	"github.com/504dev/logr-go-client/utils"
+	"runtime/debug"
+)
+
+// Recover logs a panic with its stack trace and waits until the log is sent,
+// up to DEFAULT_CLOSE_TIMEOUT. It must be deferred directly:
+//
+//	defer logger.Recover()
+//
+// The panic is logged at LevelCrit and the goroutine returns normally, or,
+// with Repanic, at LevelEmerg along with the counts, and panics again.
+func (lg *Logger) Recover() {
+	if r := recover(); r != nil {
+		lg.crash(r)
+	}
+}
+
+// Go runs f in a goroutine which recovers from its panics with Recover.
+func (lg *Logger) Go(f func()) {
+	go func() {
+		defer lg.Recover()
+		f()
+	}()
+}
+
+func (lg *Logger) crash(r interface{}) {
+	stack := debug.Stack()
+	var level types.Level = types.LevelCrit
+	if lg.Repanic {
+		level = types.LevelEmerg
+	}
+	if lg.enabled(level) {
+		// skip crash and Recover, then the runtime frames of the panic
+		initiator, caller := utils.CallerOutside(2, "runtime")
+		msg := fmt.Sprintf("panic: %v\n\n%s", r, stack)
+		log := lg.newLog(level, lg.body(msg, initiator, caller), lg.Fields.With(types.KV("panic", fmt.Sprint(r))))
+		log.Initiator = initiator
+		lg.emit(log)
+	}
+	if lg.Repanic {
+		lg.Counter.Flush()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_CLOSE_TIMEOUT)
+	defer cancel()
+	lg.Flush(ctx)
+	if lg.Repanic {
+		panic(r)
+	}
+}
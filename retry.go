@@ -0,0 +1,80 @@
+package logr_go_client
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const DEFAULT_RETRY_BACKOFF = 100 * time.Millisecond
+const DEFAULT_RETRY_MAX_BACKOFF = 10 * time.Second
+
+// backoff returns the delay before the given attempt (counting from zero):
+// exponential growth capped by RetryMaxBackoff, with half of it randomized.
+func (c *Config) backoff(attempt int) time.Duration {
+	base, max := c.RetryBackoff, c.RetryMaxBackoff
+	if base <= 0 {
+		base = DEFAULT_RETRY_BACKOFF
+	}
+	if max <= 0 {
+		max = DEFAULT_RETRY_MAX_BACKOFF
+	}
+	d := base
+	for i := 0; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retry calls fn until it succeeds, returns a permanent error, RetryAttempts
+// are used up, stop is closed or ctx is done.
+func (c *Config) retry(ctx context.Context, stop <-chan struct{}, fn func() error) error {
+	err := fn()
+	for attempt := 0; err != nil && attempt < c.RetryAttempts && retryable(err); attempt++ {
+		select {
+		case <-stop:
+			return err
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.backoff(attempt)):
+		}
+		err = fn()
+	}
+	return err
+}
+
+func retryable(err error) bool {
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// errorReporter prints errors of background sends, skipping repeats of the
+// previous one, so a dead collector doesn't flood the output.
+type errorReporter struct {
+	mu   sync.Mutex
+	last string
+}
+
+func (r *errorReporter) report(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.last = ""
+	} else if msg := err.Error(); msg != r.last {
+		r.last = msg
+		log.Println(err)
+	}
+}
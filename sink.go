@@ -0,0 +1,126 @@
+package logr_go_client
+
+import (
+	"context"
+	"github.com/504dev/logr-go-client/types"
+	gojson "github.com/goccy/go-json"
+	"io"
+	"sync"
+)
+
+// Sink is a destination of logs and counts. UdpSink and GrpcSink send them to
+// the logr server, other implementations can be added with Config.Sinks.
+type Sink interface {
+	PushLog(log *types.Log) (int, error)
+	PushCount(count *types.Count) (int, error)
+	Flush(ctx context.Context) error
+	Close() error
+}
+
+type queueItem struct {
+	log   *types.Log
+	count *types.Count
+}
+
+func (item queueItem) pushTo(sink Sink) (int, error) {
+	if item.log != nil {
+		return sink.PushLog(item.log)
+	}
+	return sink.PushCount(item.count)
+}
+
+// pack wraps the item into a package, encrypted unless NoCipher is set.
+func (c *Config) pack(item queueItem) (*types.LogPackage, error) {
+	lp := &types.LogPackage{
+		DashId:    c.DashId,
+		PublicKey: c.PublicKey,
+		Log:       item.log,
+		Count:     item.count,
+	}
+	if c.NoCipher {
+		return lp, nil
+	}
+	if item.log != nil {
+		return lp, lp.EncryptLogMode(c.PrivateKey, c.Cipher)
+	}
+	return lp, lp.EncryptCountMode(c.PrivateKey, c.Cipher)
+}
+
+// MultiSink pushes every record to each of its sinks and returns the first error.
+type MultiSink []Sink
+
+func (ms MultiSink) each(fn func(sink Sink) error) (err error) {
+	for _, sink := range ms {
+		if sinkErr := fn(sink); err == nil {
+			err = sinkErr
+		}
+	}
+	return err
+}
+
+func (ms MultiSink) PushLog(log *types.Log) (n int, err error) {
+	err = ms.each(func(sink Sink) error {
+		_, err := sink.PushLog(log)
+		return err
+	})
+	return len(ms), err
+}
+
+func (ms MultiSink) PushCount(count *types.Count) (n int, err error) {
+	err = ms.each(func(sink Sink) error {
+		_, err := sink.PushCount(count)
+		return err
+	})
+	return len(ms), err
+}
+
+func (ms MultiSink) Flush(ctx context.Context) error {
+	return ms.each(func(sink Sink) error {
+		return sink.Flush(ctx)
+	})
+}
+
+func (ms MultiSink) Close() error {
+	return ms.each(func(sink Sink) error {
+		return sink.Close()
+	})
+}
+
+// JsonSink writes records as JSON lines, e.g. to os.Stdout or a file.
+type JsonSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJsonSink(w io.Writer) *JsonSink {
+	return &JsonSink{w: w}
+}
+
+func (js *JsonSink) write(v interface{}) (int, error) {
+	line, err := gojson.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	return js.w.Write(append(line, '\n'))
+}
+
+func (js *JsonSink) PushLog(log *types.Log) (int, error) {
+	return js.write(log)
+}
+
+func (js *JsonSink) PushCount(count *types.Count) (int, error) {
+	return js.write(count)
+}
+
+func (js *JsonSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (js *JsonSink) Close() error {
+	if closer, ok := js.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
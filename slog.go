@@ -0,0 +1,135 @@
+//go:build go1.21
+
+package logr_go_client
+
+import (
+	"context"
+	"github.com/504dev/logr-go-client/types"
+	"github.com/504dev/logr-go-client/utils"
+	"log/slog"
+	"time"
+)
+
+// slog has no levels between info and warn or above error, these fill the gaps.
+const (
+	SlogLevelNotice = slog.Level(2)
+	SlogLevelCrit   = slog.Level(12)
+	SlogLevelAlert  = slog.Level(16)
+	SlogLevelEmerg  = slog.Level(20)
+)
+
+func SlogLevel(level slog.Level) types.Level {
+	switch {
+	case level >= SlogLevelEmerg:
+		return types.LevelEmerg
+	case level >= SlogLevelAlert:
+		return types.LevelAlert
+	case level >= SlogLevelCrit:
+		return types.LevelCrit
+	case level >= slog.LevelError:
+		return types.LevelError
+	case level >= slog.LevelWarn:
+		return types.LevelWarn
+	case level >= SlogLevelNotice:
+		return types.LevelNotice
+	case level >= slog.LevelInfo:
+		return types.LevelInfo
+	default:
+		return types.LevelDebug
+	}
+}
+
+// SlogHandler is a slog.Handler writing records through the Logger.
+// Attributes become log fields, groups are flattened into dotted keys.
+type SlogHandler struct {
+	logger *Logger
+	fields types.Fields
+	prefix string
+}
+
+func (lg *Logger) SlogHandler() *SlogHandler {
+	return &SlogHandler{logger: lg}
+}
+
+func (lg *Logger) Slog() *slog.Logger {
+	return slog.New(lg.SlogHandler())
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(SlogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	lg := h.logger
+	fields := lg.Fields.With(contextFields(ctx)).With(h.fields)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, a)
+		return true
+	})
+	initiator, caller := utils.FramePC(r.PC)
+	log := lg.newLog(SlogLevel(r.Level), lg.body(r.Message, initiator, caller), fields)
+	log.Initiator = initiator
+	if !r.Time.IsZero() {
+		log.Timestamp = r.Time.UnixNano()
+	}
+	_, err := lg.emit(log)
+	return err
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	tmp := *h
+	for _, a := range attrs {
+		tmp.fields = appendSlogAttr(tmp.fields, h.prefix, a)
+	}
+	return &tmp
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	tmp := *h
+	tmp.prefix = h.prefix + name + "."
+	return &tmp
+}
+
+func appendSlogAttr(fields types.Fields, prefix string, a slog.Attr) types.Fields {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	return fields.Set(prefix+a.Key, slogValue(a.Value))
+}
+
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	default:
+		return v.Any()
+	}
+}
@@ -0,0 +1,226 @@
+package spool
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/504dev/logr-go-client/types"
+	gojson "github.com/goccy/go-json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const DEFAULT_SEGMENT_SIZE = 4 << 20
+const DEFAULT_MAX_SIZE = 64 << 20
+
+const ext = ".spool"
+
+type Record struct {
+	Log   *types.Log   `json:"log,omitempty"`
+	Count *types.Count `json:"count,omitempty"`
+}
+
+// Spool is a write-ahead queue of records on disk. Records are appended as
+// JSON lines to segment files, the current segment is sealed when it grows
+// over the segment size, and the oldest segments are removed when the total
+// size goes over the cap.
+type Spool struct {
+	mu          sync.Mutex
+	dir         string
+	segmentSize int64
+	maxSize     int64
+	sealed      []string
+	sizes       map[string]int64
+	current     *os.File
+	currentPath string
+	total       int64
+	seq         uint64
+}
+
+func Open(dir string, segmentSize int64, maxSize int64) (*Spool, error) {
+	if segmentSize <= 0 {
+		segmentSize = DEFAULT_SEGMENT_SIZE
+	}
+	if maxSize <= 0 {
+		maxSize = DEFAULT_MAX_SIZE
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Spool{
+		dir:         dir,
+		segmentSize: segmentSize,
+		maxSize:     maxSize,
+		sizes:       map[string]int64{},
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+		if entry.IsDir() || !strings.HasSuffix(name, ext) || err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, name)
+		s.sealed = append(s.sealed, path)
+		s.sizes[path] = info.Size()
+		s.total += info.Size()
+		if seq >= s.seq {
+			s.seq = seq + 1
+		}
+	}
+	sort.Strings(s.sealed)
+	return s, nil
+}
+
+func (s *Spool) Append(rec Record) error {
+	line, err := gojson.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil && s.sizes[s.currentPath]+int64(len(line)) > s.segmentSize {
+		s.seal()
+	}
+	if s.current == nil {
+		path := filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.seq, ext))
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		s.seq++
+		s.current, s.currentPath = file, path
+	}
+	n, err := s.current.Write(line)
+	s.sizes[s.currentPath] += int64(n)
+	s.total += int64(n)
+	s.trim()
+	return err
+}
+
+func (s *Spool) seal() {
+	if s.current == nil {
+		return
+	}
+	s.current.Close()
+	s.sealed = append(s.sealed, s.currentPath)
+	s.current, s.currentPath = nil, ""
+}
+
+func (s *Spool) trim() {
+	for s.total > s.maxSize && len(s.sealed) > 0 {
+		s.drop(s.sealed[0])
+	}
+}
+
+func (s *Spool) drop(path string) {
+	for i, p := range s.sealed {
+		if p == path {
+			s.sealed = append(s.sealed[:i], s.sealed[i+1:]...)
+			os.Remove(path)
+			s.total -= s.sizes[path]
+			delete(s.sizes, path)
+			return
+		}
+	}
+}
+
+// Size returns the number of bytes waiting in the spool.
+func (s *Spool) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.total
+}
+
+func (s *Spool) Empty() bool {
+	return s.Size() == 0
+}
+
+// Replay passes the spooled records to fn, oldest first, and removes them
+// from the spool. It stops at the first error of fn, leaving that record and
+// the following ones for the next replay. Records appended meanwhile are
+// left for the next replay as well.
+func (s *Spool) Replay(fn func(rec Record) error) error {
+	s.mu.Lock()
+	s.seal()
+	segments := append([]string(nil), s.sealed...)
+	s.mu.Unlock()
+
+	for _, path := range segments {
+		if err := s.replaySegment(path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Spool) replaySegment(path string, fn func(rec Record) error) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for offset := 0; offset < len(data); {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			end = len(data) - offset
+		}
+		rec := Record{}
+		// lines which fail to decode are torn writes, they are skipped
+		if gojson.Unmarshal(data[offset:offset+end], &rec) == nil {
+			if err = fn(rec); err != nil {
+				s.rewrite(path, data[offset:])
+				return err
+			}
+		}
+		offset += end + 1
+	}
+	s.mu.Lock()
+	s.drop(path)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Spool) rewrite(path string, rest []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sizes[path]; !ok {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, rest, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	s.total += int64(len(rest)) - s.sizes[path]
+	s.sizes[path] = int64(len(rest))
+}
+
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		return nil
+	}
+	err := s.current.Close()
+	s.sealed = append(s.sealed, s.currentPath)
+	s.current, s.currentPath = nil, ""
+	return err
+}
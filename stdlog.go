@@ -0,0 +1,35 @@
+package logr_go_client
+
+import (
+	"github.com/504dev/logr-go-client/types"
+	"log"
+)
+
+// StdLogger returns a *log.Logger writing to lg, for the libraries which
+// want one, like http.Server.ErrorLog. The lines get the level of their
+// level token, level if there is none, and the file:line they are logged
+// from as the initiator.
+func (lg *Logger) StdLogger(level types.Level) *log.Logger {
+	w := lg.LineWriter(level)
+	w.StdFileLine = true
+	return log.New(w, "", log.Lshortfile)
+}
+
+// RedirectStdLog makes the standard logger of the log package write to lg,
+// the same way as StdLogger does. The returned function restores the
+// previous output, flags and prefix.
+func RedirectStdLog(lg *Logger, level types.Level) (restore func()) {
+	std := log.Default()
+	w := lg.LineWriter(level)
+	w.StdFileLine = true
+	output, flags, prefix := std.Writer(), std.Flags(), std.Prefix()
+	std.SetOutput(w)
+	std.SetFlags(log.Lshortfile)
+	std.SetPrefix("")
+	return func() {
+		std.SetOutput(output)
+		std.SetFlags(flags)
+		std.SetPrefix(prefix)
+		w.Close()
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestAsyncTransport(t *testing.T) {
+	collector := newUdpCollector(t)
+	conf := logr.Config{
+		Udp:            collector.Addr(),
+		NoCipher:       true,
+		AsyncQueueSize: 64,
+		AsyncOverflow:  logr.OverflowBlock,
+	}
+	logger, err := conf.NewLogger("async-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	logger.Console = false
+
+	const total = 20
+	for i := 0; i < total; i++ {
+		logger.Infow("queued", "i", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.NoError(t, logger.Flush(ctx))
+
+	for i := 0; i < total; i++ {
+		log := collector.Log()
+		assert.Equal(t, types.Fields{{Key: "i", Value: int64(i)}}, log.Fields, "records must keep their order")
+	}
+
+	assert.NoError(t, logger.Close())
+	_, err = logger.PushLog(&types.Log{Message: "too late"})
+	assert.ErrorIs(t, err, logr.ErrQueueClosed)
+	assert.Zero(t, logger.Dropped())
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	logr "github.com/504dev/logr-go-client"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestBatchTransport(t *testing.T) {
+	collector := newGrpcCollector(t)
+	conf := logr.Config{
+		Grpc:        collector.addr,
+		NoCipher:    true,
+		BatchSize:   5,
+		BatchWindow: time.Hour,
+	}
+	logger, err := conf.NewLogger("batch-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	for i := 0; i < 12; i++ {
+		logger.Info(fmt.Sprintf("message-%d", i))
+	}
+
+	_, batches := collector.Received()
+	assert.Equal(t, []int{5, 5}, batches, "full batches go out right away")
+
+	assert.NoError(t, logger.Flush(context.Background()))
+
+	packages, batches := collector.Received()
+	assert.Equal(t, []int{5, 5, 2}, batches)
+	for i, p := range packages {
+		assert.Contains(t, p.Log.Message, fmt.Sprintf("message-%d", i))
+	}
+}
+
+func TestBatchTransport_Window(t *testing.T) {
+	collector := newGrpcCollector(t)
+	conf := logr.Config{
+		Grpc:        collector.addr,
+		NoCipher:    true,
+		BatchSize:   100,
+		BatchWindow: 20 * time.Millisecond,
+	}
+	logger, err := conf.NewLogger("batch-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	logger.Info("first")
+	logger.Info("second")
+
+	assert.Eventually(t, func() bool {
+		_, batches := collector.Received()
+		return len(batches) == 1 && batches[0] == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBatchTransport_Errors(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	conf := logr.Config{
+		Grpc:        addr,
+		NoCipher:    true,
+		BatchSize:   100,
+		BatchWindow: 20 * time.Millisecond,
+	}
+	logger, _ := conf.NewLogger("batch-test.log")
+	defer logger.Close()
+	logger.Console = false
+
+	_, err = logger.PushLog(&types.Log{Message: "lost"})
+	assert.NoError(t, err, "the batch is not sent yet")
+	assert.Eventually(t, func() bool {
+		_, err = logger.PushLog(&types.Log{Message: "next"})
+		return err != nil
+	}, time.Second, 30*time.Millisecond, "the timer flush error comes with the next push")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = logger.PushLog(&types.Log{Message: "cancelled"})
+	assert.ErrorIs(t, logger.Flush(ctx), context.Canceled)
+}
+
+// unaryCollector is a logr server older than PushBatch.
+type unaryCollector struct {
+	pb.UnimplementedLogRpcServer
+	mu       sync.Mutex
+	messages []string
+}
+
+func (c *unaryCollector) Push(_ context.Context, p *pb.LogRpcPackage) (*pb.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, p.Log.Message)
+	return &pb.Response{}, nil
+}
+
+func TestBatchTransport_Unimplemented(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	collector := &unaryCollector{}
+	var batches int32
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == "/logr.LogRpc/PushBatch" {
+			atomic.AddInt32(&batches, 1)
+		}
+		return handler(ctx, req)
+	}))
+	pb.RegisterLogRpcServer(server, collector)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conf := logr.Config{
+		Grpc:        lis.Addr().String(),
+		NoCipher:    true,
+		BatchSize:   2,
+		BatchWindow: time.Hour,
+	}
+	logger, err := conf.NewLogger("batch-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	for i := 0; i < 5; i++ {
+		_, err = logger.PushLog(&types.Log{Message: fmt.Sprint(i)})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, logger.Flush(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, collector.messages, "the batches are pushed one by one")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&batches), "PushBatch is not tried again")
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/utils"
+)
+
+func TestConfig_GetVersion_Override(t *testing.T) {
+	conf := &logr.Config{Version: "v9.9.9", GitFallback: true}
+	assert.Equal(t, "v9.9.9", conf.GetVersion())
+}
+
+func TestConfig_BuildInfo_NoGitByDefault(t *testing.T) {
+	// go test builds without VCS info, and git is not asked unless enabled
+	conf := &logr.Config{}
+	info := conf.BuildInfo()
+	assert.Equal(t, "", info.Tag)
+	assert.Equal(t, "", info.Revision)
+}
+
+func TestConfig_BuildInfo_GitFallback(t *testing.T) {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Skip("git is not available")
+	}
+	conf := &logr.Config{GitFallback: true}
+	revision := strings.TrimSpace(string(out))
+	assert.Equal(t, revision, conf.BuildInfo().Revision)
+	assert.NotEqual(t, "", conf.GetVersion())
+}
+
+func TestBuildInfo_ShortVersion(t *testing.T) {
+	revision := "0123456789abcdef0123456789abcdef01234567"
+	cases := []struct {
+		info utils.BuildInfo
+		want string
+	}{
+		{utils.BuildInfo{}, ""},
+		{utils.BuildInfo{Revision: revision}, "012345"},
+		{utils.BuildInfo{Version: "v1.2.3", Revision: revision}, "v1.2.3"},
+		{utils.BuildInfo{Version: "v0.0.0-20240102150405-0123456789ab", Revision: revision}, "012345"},
+		{utils.BuildInfo{Tag: "v2.0.0", Version: "v1.2.3", Revision: revision}, "v2.0.0"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, c.info.ShortVersion(), "%+v", c.info)
+	}
+}
+
+func TestReadGitHeadDir(t *testing.T) {
+	revision := "0123456789abcdef0123456789abcdef01234567"
+	write := func(dir string, name string, data string) {
+		path := filepath.Join(dir, ".git", filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	}
+
+	detached := t.TempDir()
+	write(detached, "HEAD", revision+"\n")
+	assert.Equal(t, revision, utils.ReadGitHeadDir(detached))
+
+	branch := t.TempDir()
+	write(branch, "HEAD", "ref: refs/heads/main\n")
+	write(branch, "refs/heads/main", revision+"\n")
+	assert.Equal(t, revision, utils.ReadGitHeadDir(branch))
+
+	packed := t.TempDir()
+	write(packed, "HEAD", "ref: refs/heads/main\n")
+	write(packed, "packed-refs", "# pack-refs with: peeled fully-peeled sorted\n"+revision+" refs/heads/main\n")
+	assert.Equal(t, revision, utils.ReadGitHeadDir(packed))
+
+	unborn := t.TempDir()
+	write(unborn, "HEAD", "ref: refs/heads/main\n")
+	assert.Equal(t, "", utils.ReadGitHeadDir(unborn))
+	assert.Equal(t, "", utils.ReadGitCommitDir(unborn))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+	"github.com/504dev/logr-go-client/utils"
+)
+
+// nextLine returns the initiator of the line following its call.
+func nextLine() string {
+	_, file, line, _ := runtime.Caller(1)
+	return filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file) + ":" + strconv.Itoa(line+1)
+}
+
+func lastInitiator(rec *logrtest.Recorder) string {
+	logs := rec.Logs()
+	return logs[len(logs)-1].Initiator
+}
+
+func TestLogger_Caller(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "caller.log")
+	logger.Body = "{caller} {message}"
+
+	line := nextLine()
+	logger.Info("info")
+	assert.Equal(t, line, lastInitiator(rec))
+	assert.Equal(t, "tests.TestLogger_Caller info", rec.Logs()[0].Message)
+
+	line = nextLine()
+	logger.Log(types.LevelWarn, "log")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.InfoErr(errors.New("boom"), "info err")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.Infow("infow", "k", "v")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.Logw(types.LevelInfo, "logw")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.InfoCtx(context.Background(), "info ctx")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.LogwCtx(context.Background(), types.LevelInfo, "logw ctx")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	logger.With("k", "v").Of("other.log").Error("child")
+	assert.Equal(t, line, lastInitiator(rec))
+
+	line = nextLine()
+	fmt.Fprint(logger.DefaultWriter(), "writer")
+	assert.Equal(t, line, lastInitiator(rec))
+}
+
+func logFailure(logger *logr.Logger, msg string) {
+	logger.WithCallerSkip(1).Error(msg)
+}
+
+func TestLogger_WithCallerSkip(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "caller.log")
+
+	line := nextLine()
+	logFailure(logger, "wrapped")
+	assert.Equal(t, line, lastInitiator(rec))
+}
+
+func TestCaller_ShallowStack(t *testing.T) {
+	initiator, caller := utils.Caller(1000)
+	assert.Equal(t, "", initiator)
+	assert.Equal(t, "", caller)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/aes"
+	stdcipher "crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/504dev/logr-go-client/cipher"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestCipher_Gcm(t *testing.T) {
+	key := []byte("secret")
+	cipherText, err := cipher.EncryptAesGcm([]byte("hello"), key)
+	assert.NoError(t, err)
+	assert.Equal(t, cipher.VersionGcm, cipherText[0])
+
+	plainText, err := cipher.DecryptAes(cipherText, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(plainText))
+
+	tampered := append([]byte(nil), cipherText...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = cipher.DecryptAesMode(tampered, key, cipher.ModeGcm)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity)
+
+	_, err = cipher.DecryptAesMode(cipherText, []byte("other"), cipher.ModeGcm)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity)
+
+	_, err = cipher.DecryptAesMode(cipherText[:10], key, cipher.ModeGcm)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity)
+
+	_, err = cipher.DecryptAesMode(mustEncryptAes(t, "hello", key), key, cipher.ModeGcm)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity, "AES-CFB is refused in the AES-GCM mode")
+
+	_, err = cipher.DecryptAes(tampered, key)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity, "tampering is detected by default too")
+	_, err = cipher.DecryptAes(cipherText, []byte("other"))
+	assert.ErrorIs(t, err, cipher.ErrIntegrity)
+}
+
+func mustEncryptAes(t *testing.T, text string, key []byte) []byte {
+	t.Helper()
+	cipherText, err := cipher.EncryptAes([]byte(text), key)
+	assert.NoError(t, err)
+	return cipherText
+}
+
+func TestCipher_CfbRandomIv(t *testing.T) {
+	key := []byte("secret")
+	first := mustEncryptAes(t, "hello", key)
+	second := mustEncryptAes(t, "hello", key)
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, make([]byte, aes.BlockSize), first[:aes.BlockSize])
+}
+
+// The older clients started 1 in 256 AES-CFB ciphertexts with VersionGcm.
+func TestCipher_CfbStartingWithVersionGcm(t *testing.T) {
+	key := []byte("secret")
+	priv := base64.StdEncoding.EncodeToString(key)
+	hash := sha256.Sum256(key)
+	block, err := aes.NewCipher(hash[:])
+	assert.NoError(t, err)
+	plain := `{"message":"hello"}`
+	cipherText := make([]byte, aes.BlockSize+len(plain))
+	cipherText[0] = cipher.VersionGcm
+	cipherText[1] = 0x42
+	stdcipher.NewCFBEncrypter(block, cipherText[:aes.BlockSize]).XORKeyStream(cipherText[aes.BlockSize:], []byte(plain))
+
+	log := types.Log{}
+	assert.NoError(t, log.Decrypt(cipherText, priv), "the JSON of AES-CFB is accepted")
+	assert.Equal(t, "hello", log.Message)
+
+	plainText, err := cipher.DecryptAesMode(cipherText, key, cipher.ModeCfb)
+	assert.NoError(t, err)
+	assert.Equal(t, plain, string(plainText))
+
+	_, err = cipher.DecryptAes(cipherText, key)
+	assert.ErrorIs(t, err, cipher.ErrIntegrity, "without JSON to check it is taken for AES-GCM")
+}
+
+func TestCipher_CfbNeverLooksLikeGcm(t *testing.T) {
+	key := []byte("secret")
+	seen := map[byte]bool{}
+	for i := 0; i < 1000; i++ {
+		cipherText, err := cipher.EncryptAes([]byte("hello"), key)
+		assert.NoError(t, err)
+		seen[cipherText[0]] = true
+		assert.NotEqual(t, cipher.VersionGcm, cipherText[0])
+		plainText, err := cipher.DecryptAes(cipherText, key)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(plainText))
+	}
+	assert.Greater(t, len(seen), 100, "the IVs are random")
+}
+
+func TestCipher_LogPackageGcm(t *testing.T) {
+	priv := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	lp := types.LogPackage{
+		Log: &types.Log{Level: types.LevelInfo, Message: "hello"},
+	}
+	assert.NoError(t, lp.EncryptLogMode(priv, cipher.ModeGcm))
+	assert.Equal(t, cipher.VersionGcm, lp.CipherLog[0])
+
+	received := types.LogPackage{CipherLog: lp.CipherLog}
+	assert.NoError(t, received.DecryptLog(priv))
+	assert.Equal(t, "hello", received.Log.Message)
+
+	received.CipherLog[5] ^= 1
+	assert.ErrorIs(t, received.DecryptLogMode(priv, cipher.ModeGcm), cipher.ErrIntegrity)
+	assert.ErrorIs(t, received.DecryptLog(priv), cipher.ErrIntegrity)
+
+	count := &types.Count{Keyname: "requests"}
+	cp := types.LogPackage{Count: count}
+	assert.NoError(t, cp.EncryptCountMode(priv, cipher.ModeGcm))
+	cp.CipherCount[len(cp.CipherCount)-1] ^= 1
+	assert.ErrorIs(t, cp.DecryptCount(priv), cipher.ErrIntegrity)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestLogger_Ctx(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "ctx.log")
+	logger.Body = "{message}"
+	logger = logger.With("service", "api")
+
+	ctx := logr.NewContext(context.Background(), types.KV("request_id", "r1"))
+	ctx = logr.NewContext(ctx, types.KV("tenant_id", "t1"))
+	tc, err := logr.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	ctx = logr.NewTraceContext(ctx, tc)
+
+	logger.InfoCtx(ctx, "hello ctx")
+	logger.Of("db.log").LogwCtx(ctx, types.LevelWarn, "slow query", "ms", 120)
+	logger.Info("no ctx")
+
+	logs := rec.Logs()
+	assert.Len(t, logs, 3)
+	assert.Equal(t, "hello ctx", logs[0].Message)
+	assert.Equal(t, `service=api request_id=r1 tenant_id=t1 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7`, logs[0].Fields.String())
+
+	assert.Equal(t, "db.log", logs[1].Logname)
+	assert.Equal(t, `service=api request_id=r1 tenant_id=t1 trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 ms=120`, logs[1].Fields.String())
+
+	assert.Equal(t, `service=api`, logs[2].Fields.String())
+}
+
+func TestNewContext_Override(t *testing.T) {
+	parent := logr.NewContext(context.Background(), types.KV("request_id", "r1", "user", "bob"))
+	child := logr.NewContext(parent, types.KV("user", "alice"))
+
+	assert.Equal(t, `request_id=r1 user=bob`, logr.FieldsFromContext(parent).String())
+	assert.Equal(t, `request_id=r1 user=alice`, logr.FieldsFromContext(child).String())
+	assert.Nil(t, logr.FieldsFromContext(context.Background()))
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tc, err := logr.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceId)
+	assert.Equal(t, "00f067aa0ba902b7", tc.SpanId)
+	assert.True(t, tc.Sampled())
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", tc.Traceparent())
+
+	// a future version may add fields
+	tc, err = logr.ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra")
+	assert.NoError(t, err)
+	assert.False(t, tc.Sampled())
+
+	for _, header := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+	} {
+		_, err := logr.ParseTraceparent(header)
+		assert.ErrorIs(t, err, logr.ErrBadTraceparent, header)
+	}
+}
+
+func TestTraceContext_Child(t *testing.T) {
+	tc := logr.NewTrace()
+	assert.True(t, tc.Valid())
+	assert.True(t, tc.Sampled())
+	assert.Len(t, tc.TraceId, 32)
+	assert.Len(t, tc.SpanId, 16)
+
+	child := tc.Child()
+	assert.Equal(t, tc.TraceId, child.TraceId)
+	assert.NotEqual(t, tc.SpanId, child.SpanId)
+
+	parsed, err := logr.ParseTraceparent(child.Traceparent())
+	assert.NoError(t, err)
+	assert.Equal(t, child, parsed)
+}
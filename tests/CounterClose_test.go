@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestCounter_Close(t *testing.T) {
+	conf := &logr.Config{}
+	rec := logrtest.NewRecorder(conf)
+	conf.Sinks = []logr.Sink{rec}
+	counter, _ := conf.NewCounter("close.log")
+
+	counter.Inc("flushed", 1)
+	counter.Flush()
+	counter.Inc("pending", 2)
+
+	assert.NoError(t, counter.Close(context.Background()))
+	assert.Equal(t, 1.0, rec.CounterValue("flushed", logr.KIND_INC))
+	assert.Equal(t, 2.0, rec.CounterValue("pending", logr.KIND_INC), "the last window is pushed on close")
+	assert.NoError(t, counter.Close(context.Background()), "closing twice is fine")
+}
+
+func TestLogger_CloseFlushesCounter(t *testing.T) {
+	var buf syncBuffer
+	conf := &logr.Config{Sinks: []logr.Sink{logr.NewJsonSink(&buf)}}
+	logger, _ := conf.NewLogger("close.log")
+	logger.Console = false
+
+	logger.Inc("deploys", 1)
+	start := time.Now()
+	assert.NoError(t, logger.Close())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Contains(t, buf.String(), `"keyname":"deploys"`)
+}
+
+type slowSink struct {
+	logr.Sink
+	release chan struct{}
+}
+
+func (s slowSink) PushCount(count *types.Count) (int, error) {
+	<-s.release
+	return 0, nil
+}
+
+func TestCounter_CloseTimeout(t *testing.T) {
+	sink := slowSink{Sink: logr.NewJsonSink(io.Discard), release: make(chan struct{})}
+	defer close(sink.release)
+	conf := &logr.Config{Sinks: []logr.Sink{sink}}
+	counter, _ := conf.NewCounter("close-timeout.log")
+	counter.Inc("stuck", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, counter.Close(ctx), context.DeadlineExceeded)
+}
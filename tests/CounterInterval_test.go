@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/types"
+)
+
+// pushTimes remembers when counts are pushed to it.
+type pushTimes struct {
+	logr.Sink
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (p *pushTimes) PushCount(count *types.Count) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.times = append(p.times, time.Now())
+	return 0, nil
+}
+
+func (p *pushTimes) Times() []time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]time.Time(nil), p.times...)
+}
+
+func TestCounter_Interval(t *testing.T) {
+	sink := &pushTimes{Sink: logr.NewJsonSink(io.Discard)}
+	conf := &logr.Config{Sinks: []logr.Sink{sink}, CounterInterval: 50 * time.Millisecond}
+	counter, _ := conf.NewCounter("interval.log")
+	defer counter.Close(context.Background())
+
+	counter.Inc("ticks", 1)
+	assert.Eventually(t, func() bool { return len(sink.Times()) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestCounter_AlignedInterval(t *testing.T) {
+	const interval = 300 * time.Millisecond
+	sink := &pushTimes{Sink: logr.NewJsonSink(io.Discard)}
+	conf := &logr.Config{Sinks: []logr.Sink{sink}, CounterInterval: time.Hour}
+	counter, _ := conf.NewCounter("aligned.log")
+	defer counter.Close(context.Background())
+	counter.SetInterval(interval, true)
+
+	for i := 0; i < 2; i++ {
+		counter.Inc("ticks", 1)
+		time.Sleep(interval)
+	}
+	times := sink.Times()
+	assert.NotEmpty(t, times)
+	for _, pushed := range times {
+		offset := pushed.Sub(pushed.Truncate(interval))
+		assert.Less(t, offset, 100*time.Millisecond, "pushed at %s", pushed.Format("15:04:05.000"))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestDestinations(t *testing.T) {
+	primary := newUdpCollector(t)
+	secondary := newUdpCollector(t)
+	errorsOnly := newUdpCollector(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	dead := lis.Addr().String()
+	lis.Close()
+
+	conf := logr.Config{
+		Udp:           primary.Addr(),
+		NoCipher:      true,
+		RetryAttempts: 100,
+		Destinations: []logr.Destination{
+			{Grpc: dead},
+			{Udp: secondary.Addr()},
+			{Udp: errorsOnly.Addr(), Level: types.LevelError, NoCounts: true},
+		},
+	}
+	logger, err := conf.NewLogger("destinations-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	start := time.Now()
+	logger.Info("for everyone")
+	logger.Error("for the errors too")
+	assert.Less(t, time.Since(start), time.Second, "a dead destination must not hold back the others")
+
+	assert.Equal(t, string(types.LevelInfo), primary.Log().Level)
+	assert.Equal(t, string(types.LevelError), primary.Log().Level)
+	assert.Equal(t, string(types.LevelInfo), secondary.Log().Level)
+	assert.Equal(t, string(types.LevelError), secondary.Log().Level)
+	assert.Equal(t, string(types.LevelError), errorsOnly.Log().Level)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, logger.Flush(ctx), context.DeadlineExceeded, "the dead destination is still retrying")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestFields_KV(t *testing.T) {
+	fields := types.KV("user_id", 42, "shard", "eu-1", "err", errors.New("boom"), "dangling")
+
+	assert.Equal(t, types.Fields{
+		{Key: "user_id", Value: 42},
+		{Key: "shard", Value: "eu-1"},
+		{Key: "err", Value: "boom"},
+		{Key: "dangling", Value: nil},
+	}, fields)
+	assert.Equal(t, `user_id=42 shard=eu-1 err=boom dangling=null`, fields.String())
+
+	child := fields.With(types.KV("shard", "us-2", "request_id", "abc"))
+	assert.Equal(t, "eu-1", fields[1].Value, "parent fields must stay untouched")
+	assert.Equal(t, `user_id=42 shard=us-2 err=boom dangling=null request_id=abc`, child.String())
+}
+
+func TestFields_JSON(t *testing.T) {
+	log := types.Log{
+		Message: "hello",
+		Fields:  types.KV("zeta", 1, "alpha", "two words", "pi", 3.14, "ok", true),
+	}
+
+	data, err := gojson.Marshal(log)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"fields":{"zeta":1,"alpha":"two words","pi":3.14,"ok":true}`)
+
+	var decoded types.Log
+	assert.NoError(t, gojson.Unmarshal(data, &decoded))
+	assert.Equal(t, types.Fields{
+		{Key: "zeta", Value: int64(1)},
+		{Key: "alpha", Value: "two words"},
+		{Key: "pi", Value: 3.14},
+		{Key: "ok", Value: true},
+	}, decoded.Fields)
+
+	data, err = gojson.Marshal(types.Log{Message: "plain"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), `"fields"`)
+}
+
+func TestFields_Proto(t *testing.T) {
+	lp := types.LogPackage{
+		Log: &types.Log{
+			Message: "hello",
+			Fields:  types.KV("user_id", 42, "ratio", 0.5, "name", "bob", "ok", false, "nothing", nil),
+		},
+	}
+
+	var decoded types.LogPackage
+	decoded.FromProto(lp.Proto())
+
+	assert.Equal(t, types.Fields{
+		{Key: "user_id", Value: int64(42)},
+		{Key: "ratio", Value: 0.5},
+		{Key: "name", Value: "bob"},
+		{Key: "ok", Value: false},
+		{Key: "nothing", Value: nil},
+	}, decoded.Log.Fields)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/types"
+)
+
+// tracingRpc is a LogRpc server failing the pushes of the "fail" key and
+// remembering the trace contexts it gets.
+type tracingRpc struct {
+	pb.UnimplementedLogRpcServer
+	mu     sync.Mutex
+	traces []logr.TraceContext
+}
+
+func (s *tracingRpc) trace(ctx context.Context) {
+	tc, _ := logr.TraceFromContext(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.traces = append(s.traces, tc)
+}
+
+func (s *tracingRpc) Push(ctx context.Context, p *pb.LogRpcPackage) (*pb.Response, error) {
+	s.trace(ctx)
+	if p.PublicKey == "fail" {
+		return nil, status.Error(codes.Internal, "boom")
+	}
+	if p.PublicKey == "bad" {
+		return nil, status.Error(codes.InvalidArgument, "bad key")
+	}
+	return &pb.Response{}, nil
+}
+
+// pushStream is a client-streaming RPC for the stream interceptors, LogRpc has none.
+var pushStream = grpc.ServiceDesc{
+	ServiceName: "logrtest.Stream",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Push",
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			srv.(*tracingRpc).trace(stream.Context())
+			for {
+				if err := stream.RecvMsg(&pb.LogRpcPackage{}); err == io.EOF {
+					return stream.SendMsg(&pb.Response{})
+				} else if err != nil {
+					return err
+				}
+			}
+		},
+	}},
+}
+
+func newInterceptedRpc(t *testing.T, server *logr.GrpcInterceptor, client *logr.GrpcInterceptor) (*tracingRpc, *grpc.ClientConn) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(server.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(server.StreamServerInterceptor()),
+	)
+	rpc := &tracingRpc{}
+	pb.RegisterLogRpcServer(s, rpc)
+	s.RegisterService(&pushStream, rpc)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(client.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(client.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rpc, conn
+}
+
+func TestGrpcInterceptor(t *testing.T) {
+	serverLogger, serverRec := logrtest.NewLogger(t, "grpc-server.log")
+	clientLogger, clientRec := logrtest.NewLogger(t, "grpc-client.log")
+	rpc, conn := newInterceptedRpc(t, serverLogger.GrpcInterceptor(), clientLogger.GrpcInterceptor())
+	client := pb.NewLogRpcClient(conn)
+
+	trace := logr.NewTrace()
+	ctx := logr.NewTraceContext(context.Background(), trace)
+	_, err := client.Push(ctx, &pb.LogRpcPackage{PublicKey: "ok"})
+	assert.NoError(t, err)
+	_, err = client.Push(ctx, &pb.LogRpcPackage{PublicKey: "fail"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	_, err = client.Push(ctx, &pb.LogRpcPackage{PublicKey: "bad"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := conn.NewStream(ctx, &pushStream.Streams[0], "/logrtest.Stream/Push")
+	assert.NoError(t, err)
+	assert.NoError(t, stream.SendMsg(&pb.LogRpcPackage{PublicKey: "ok"}))
+	assert.NoError(t, stream.CloseSend())
+	assert.NoError(t, stream.RecvMsg(&pb.Response{}))
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	logrtest.Flush(t, serverLogger)
+	logrtest.Flush(t, clientLogger)
+
+	for _, rec := range []*logrtest.Recorder{serverRec, clientRec} {
+		logs := rec.Logs()
+		assert.Len(t, logs, 4, "the health check is skipped")
+		assert.Equal(t, string(types.LevelInfo), logs[0].Level)
+		assert.Equal(t, string(types.LevelError), logs[1].Level)
+		assert.Equal(t, string(types.LevelWarn), logs[2].Level)
+		assert.Equal(t, string(types.LevelInfo), logs[3].Level)
+		assert.Contains(t, logs[1].Fields.String(), "method=/logr.LogRpc/Push code=Internal")
+		assert.Contains(t, logs[1].Fields.String(), "error=boom")
+		assert.Contains(t, logs[3].Message, "/logrtest.Stream/Push OK")
+	}
+	assert.Contains(t, serverRec.Logs()[0].Fields.String(), "trace_id="+trace.TraceId)
+	assert.Contains(t, serverRec.Logs()[0].Fields.String(), "peer=127.0.0.1:")
+
+	push := logr.Labels{"method": "/logr.LogRpc/Push"}
+	assert.Equal(t, 1.0, serverRec.CounterValue("grpc.server.calls", logr.KIND_INC, push, logr.Labels{"code": "Internal"}))
+	assert.Equal(t, 4.0, serverRec.CounterValue("grpc.server.calls", logr.KIND_INC))
+	assert.InDelta(t, 100.0/3, serverRec.CounterValue("grpc.server.errors", logr.KIND_PER, push), 0.01)
+	assert.Equal(t, 1.0, clientRec.CounterValue("grpc.client.calls", logr.KIND_INC, logr.Labels{"method": "/logrtest.Stream/Push", "code": "OK"}))
+	assert.Greater(t, clientRec.CounterValue("grpc.client.latency", logr.KIND_MAX, push), 0.0)
+
+	rpc.mu.Lock()
+	defer rpc.mu.Unlock()
+	for _, tc := range rpc.traces {
+		assert.Equal(t, trace.TraceId, tc.TraceId)
+		assert.NotEqual(t, trace.SpanId, tc.SpanId)
+	}
+}
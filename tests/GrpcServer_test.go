@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+)
+
+// grpcCollector is an in-process LogRpc server remembering what it was sent.
+type grpcCollector struct {
+	pb.UnimplementedLogRpcServer
+	mu       sync.Mutex
+	packages []*pb.LogRpcPackage
+	batches  []int
+	addr     string
+}
+
+func newGrpcCollector(t *testing.T) *grpcCollector {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	c := &grpcCollector{addr: lis.Addr().String()}
+	server := grpc.NewServer()
+	pb.RegisterLogRpcServer(server, c)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return c
+}
+
+func (c *grpcCollector) Push(_ context.Context, p *pb.LogRpcPackage) (*pb.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packages = append(c.packages, p)
+	c.batches = append(c.batches, 1)
+	return &pb.Response{}, nil
+}
+
+func (c *grpcCollector) PushBatch(_ context.Context, b *pb.LogRpcBatch) (*pb.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.packages = append(c.packages, b.Packages...)
+	c.batches = append(c.batches, len(b.Packages))
+	return &pb.Response{}, nil
+}
+
+func (c *grpcCollector) Received() (packages []*pb.LogRpcPackage, batches []int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append(packages, c.packages...), append(batches, c.batches...)
+}
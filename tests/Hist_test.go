@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func assertRelative(t *testing.T, expected float64, actual float64) {
+	t.Helper()
+	assert.LessOrEqual(t, math.Abs(actual-expected), math.Abs(expected)*types.HIST_ACCURACY, "expected %v, got %v", expected, actual)
+}
+
+func TestHist_Quantile(t *testing.T) {
+	hist := types.Hist{}
+	assert.Equal(t, 0.0, hist.Quantile(0.5))
+
+	for i := 1; i <= 10000; i++ {
+		hist.Add(float64(i) / 1000)
+	}
+	assert.Equal(t, uint64(10000), hist.Num)
+	assertRelative(t, 5.0, hist.Quantile(0.5))
+	assertRelative(t, 9.5, hist.Quantile(0.95))
+	assertRelative(t, 9.9, hist.Quantile(0.99))
+	assertRelative(t, 0.001, hist.Quantile(0))
+	assertRelative(t, 10, hist.Quantile(1))
+
+	signed := types.Hist{}
+	for _, v := range []float64{-100, -10, 0, 0, 10} {
+		signed.Add(v)
+	}
+	assertRelative(t, -100, signed.Quantile(0))
+	assertRelative(t, -10, signed.Quantile(0.25))
+	assert.Equal(t, 0.0, signed.Quantile(0.5))
+	assertRelative(t, 10, signed.Quantile(1))
+}
+
+func TestHist_Merge(t *testing.T) {
+	a, b, all := types.Hist{}, types.Hist{}, types.Hist{}
+	for i := 1; i <= 1000; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i * 10))
+		all.Add(float64(i))
+		all.Add(float64(i * 10))
+	}
+	a.Merge(&b)
+	assert.Equal(t, all, a)
+}
+
+func TestHist_Serialization(t *testing.T) {
+	count := &types.Count{Keyname: "latency"}
+	count.Hist(0.25).Hist(1.5).Hist(-3).Hist(0)
+
+	data, err := gojson.Marshal(count)
+	assert.NoError(t, err)
+	decoded := &types.Count{}
+	assert.NoError(t, gojson.Unmarshal(data, decoded))
+	assert.Equal(t, count.Metrics.Hist, decoded.Metrics.Hist)
+
+	lp := types.LogPackage{Count: count}
+	received := types.LogPackage{}
+	received.FromProto(lp.Proto())
+	assert.Equal(t, count.Metrics.Hist, received.Count.Metrics.Hist)
+}
+
+func TestCounter_Hist(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "hist.log")
+	for i := 1; i <= 100; i++ {
+		logger.Hist("latency", float64(i))
+	}
+	logrtest.Flush(t, logger)
+	for i := 101; i <= 200; i++ {
+		logger.Hist("latency", float64(i))
+	}
+	logrtest.Flush(t, logger)
+
+	assertRelative(t, 100, rec.CounterValue("latency", logr.KIND_HIST))
+	assertRelative(t, 198, rec.CounterQuantile("latency", 0.99))
+
+	var snippet logr.Snippet
+	assert.NoError(t, gojson.Unmarshal([]byte(logger.Snippet(logr.KIND_HIST, "latency", 30)), &snippet))
+	assert.Empty(t, snippet.Error)
+	assert.Equal(t, logr.Kind(logr.KIND_HIST), snippet.Kind)
+}
+
+func TestHist_NonFinite(t *testing.T) {
+	count := &types.Count{Keyname: "latency"}
+	count.Hist(math.NaN()).Hist(math.Inf(1)).Hist(math.Inf(-1))
+	assert.Nil(t, count.Metrics.Hist)
+
+	count.Hist(2).Hist(math.NaN()).Hist(math.Inf(1)).Hist(math.Inf(-1))
+	assert.Equal(t, uint64(1), count.Metrics.Hist.Num)
+	assert.Equal(t, 2.0, count.Metrics.Hist.Sum)
+	assert.Equal(t, uint64(0), count.Metrics.Hist.Zero)
+
+	_, err := gojson.Marshal(count)
+	assert.NoError(t, err)
+
+	hist := types.Hist{}
+	hist.Add(math.NaN())
+	assert.Equal(t, types.Hist{}, hist)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestHttpMiddleware(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "http.log")
+	handler := logger.HttpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte("hello"))
+		}
+	}))
+
+	for _, path := range []string{"/ok", "/ok", "/missing", "/fail"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path+"?q=1", nil))
+	}
+	logrtest.Flush(t, logger)
+
+	logs := rec.Logs()
+	assert.Len(t, logs, 4)
+	assert.Equal(t, string(types.LevelInfo), logs[0].Level)
+	assert.True(t, strings.HasPrefix(logs[0].Message, "GET /ok 200 5B "), logs[0].Message)
+	assert.NotContains(t, logs[0].Message, "q=1", "query strings may carry secrets")
+	assert.Equal(t, string(types.LevelWarn), logs[2].Level)
+	assert.Equal(t, string(types.LevelError), logs[3].Level)
+
+	fields := map[string]interface{}{}
+	for _, f := range logs[0].Fields {
+		fields[f.Key] = f.Value
+	}
+	assert.Equal(t, "GET", fields["method"])
+	assert.Equal(t, "/ok", fields["path"])
+	assert.EqualValues(t, 200, fields["status"])
+	assert.EqualValues(t, 5, fields["bytes"])
+	assert.Equal(t, "192.0.2.1:1234", fields["remote_addr"])
+	assert.Contains(t, fields, "duration_ms")
+
+	ok := logr.Labels{"method": "GET", "route": "/ok", "status": "200"}
+	assert.Equal(t, 2.0, rec.CounterValue("http.requests", logr.KIND_INC, ok))
+	assert.Equal(t, 4.0, rec.CounterValue("http.requests", logr.KIND_INC))
+	assert.Equal(t, 100.0, rec.CounterValue("http.errors", logr.KIND_PER, logr.Labels{"route": "/fail"}))
+	assert.Equal(t, 0.0, rec.CounterValue("http.errors", logr.KIND_PER, logr.Labels{"route": "/ok"}))
+	assert.Equal(t, 25.0, rec.CounterValue("http.errors", logr.KIND_PER))
+	assert.Greater(t, rec.CounterValue("http.latency", logr.KIND_MAX, logr.Labels{"route": "/ok"}), 0.0)
+}
+
+func TestHttpMiddleware_Options(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "http.log")
+	mw := logger.HttpMiddleware()
+	mw.Metric = "api"
+	mw.Route = func(r *http.Request) string { return "/users/{id}" }
+	mw.Level = func(status int) types.Level { return types.LevelDebug }
+	mw.Skip = func(r *http.Request) bool { return r.URL.Path == "/healthz" }
+
+	var ctx context.Context
+	handler := mw.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			ctx = r.Context()
+		}
+	}))
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil))
+	logrtest.Flush(t, logger)
+
+	tc, ok := logr.TraceFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceId)
+	assert.NotEqual(t, "00f067aa0ba902b7", tc.SpanId)
+
+	logs := rec.Logs()
+	assert.Len(t, logs, 1)
+	assert.Equal(t, string(types.LevelDebug), logs[0].Level)
+	assert.Contains(t, logs[0].Fields.String(), "trace_id=4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, 1.0, rec.CounterValue("api.requests", logr.KIND_INC, logr.Labels{"method": "GET", "route": "/users/{id}", "status": "200"}))
+}
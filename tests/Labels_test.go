@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestLabels_Key(t *testing.T) {
+	a := types.Labels{"route": "/api", "code": "200"}
+	b := types.Labels{"code": "200", "route": "/api"}
+	assert.Equal(t, `http.requests{code="200",route="/api"}`, a.Key("http.requests"))
+	assert.Equal(t, a.Key("http.requests"), b.Key("http.requests"))
+	assert.Equal(t, "http.requests", types.Labels(nil).Key("http.requests"))
+	assert.Equal(t, types.Labels{"code": "500", "route": "/api"}, types.MergeLabels(a, types.Labels{"code": "500"}))
+}
+
+func TestLabels_Serialization(t *testing.T) {
+	count := &types.Count{Keyname: "http.requests", Labels: types.Labels{"code": "200"}}
+	count.Inc(1)
+
+	data, err := gojson.Marshal(count)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"labels":{"code":"200"}`)
+
+	lp := types.LogPackage{Count: count}
+	received := types.LogPackage{}
+	received.FromProto(lp.Proto())
+	assert.Equal(t, count.Labels, received.Count.Labels)
+}
+
+func TestCounter_Labels(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "labels.log")
+	logger.Inc("http.requests", 1, logr.Labels{"route": "/api", "code": "200"})
+	logger.Inc("http.requests", 1, logr.Labels{"code": "200", "route": "/api"})
+	logger.Inc("http.requests", 1, logr.Labels{"route": "/api"}, logr.Labels{"code": "500"})
+	logger.Inc("http.requests", 1)
+
+	assert.Len(t, logger.Counter.State, 3)
+	logrtest.Flush(t, logger)
+
+	assert.Equal(t, 4.0, rec.CounterValue("http.requests", logr.KIND_INC))
+	assert.Equal(t, 2.0, rec.CounterValue("http.requests", logr.KIND_INC, logr.Labels{"route": "/api", "code": "200"}))
+	assert.Equal(t, 1.0, rec.CounterValue("http.requests", logr.KIND_INC, logr.Labels{"route": "/api", "code": "500"}))
+}
+
+func TestCounter_LabelsCap(t *testing.T) {
+	conf := &logr.Config{MaxLabelSets: 3}
+	counter, _ := conf.NewCounter("labels-cap.log")
+	defer counter.Close(context.Background())
+
+	for i := 0; i < 10; i++ {
+		counter.Inc("by.user", 1, logr.Labels{"user": fmt.Sprint(i)})
+	}
+	assert.Len(t, counter.State, 4)
+	assert.Equal(t, 7.0, counter.State[logr.OVERFLOW_LABELS.Key("by.user")].Metrics.Inc.Val)
+
+	counter.Flush()
+	for _, user := range []string{"1", "new", "newer", "newest"} {
+		counter.Inc("by.user", 1, logr.Labels{"user": user})
+	}
+	assert.NotNil(t, counter.State[logr.Labels{"user": "new"}.Key("by.user")], "the cap is per window")
+	assert.Nil(t, counter.State[logr.Labels{"user": "newest"}.Key("by.user")])
+	assert.Equal(t, 1.0, counter.State[logr.OVERFLOW_LABELS.Key("by.user")].Metrics.Inc.Val)
+}
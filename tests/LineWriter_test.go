@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func recorded(rec *logrtest.Recorder) []string {
+	var res []string
+	for _, log := range rec.Logs() {
+		res = append(res, log.Level+" "+log.Message)
+	}
+	return res
+}
+
+func TestLineWriter(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "linewriter.log")
+	w := logger.LineWriter(types.LevelInfo)
+
+	fmt.Fprint(w, "first line\nERROR second line\n[warn] third")
+	assert.Equal(t, []string{"info first line", "error second line"}, recorded(rec))
+
+	fmt.Fprint(w, " line\ndebug: fourth\r\nerror is not a token without a colon\n\nWARNING: sixth\npartial")
+	assert.NoError(t, w.Close())
+	assert.Equal(t, []string{
+		"info first line",
+		"error second line",
+		"warn third line",
+		"debug fourth",
+		"info error is not a token without a colon",
+		"warn sixth",
+		"info partial",
+	}, recorded(rec))
+}
+
+func TestLineWriter_StdLog(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "linewriter.log")
+	w := logger.LineWriter(types.LevelNotice)
+	std := log.New(w, "", log.LstdFlags|log.Lmicroseconds)
+
+	std.Println("started")
+	std.Printf("[ERROR] failed\nwith details")
+	assert.Equal(t, []string{"notice started", "error failed", "notice with details"}, recorded(rec))
+}
+
+func TestLineWriter_Rules(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "linewriter.log")
+	logger.Level = types.LevelInfo
+	w := logger.LineWriter(types.LevelInfo)
+	w.Rules = []logr.LevelRule{
+		{Pattern: regexp.MustCompile(`level=error`), Level: types.LevelError},
+		{Pattern: regexp.MustCompile(`level=debug`), Level: types.LevelDebug},
+	}
+
+	fmt.Fprintln(w, "msg=boom level=error")
+	fmt.Fprintln(w, "msg=noise level=debug")
+	fmt.Fprintln(w, "ERROR no default rules")
+	assert.Equal(t, []string{"error msg=boom level=error", "info ERROR no default rules"}, recorded(rec))
+}
+
+func TestLineWriter_LongLine(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "linewriter.log")
+	w := logger.LineWriter(types.LevelInfo)
+
+	long := strings.Repeat("é", logr.MAX_MESSAGE_SIZE)
+	for i := 0; i < len(long); i += 1000 {
+		end := i + 1000
+		if end > len(long) {
+			end = len(long)
+		}
+		fmt.Fprint(w, long[i:end])
+	}
+	fmt.Fprint(w, "\nshort\n")
+
+	var joined string
+	logs := rec.Logs()
+	for _, log := range logs[:len(logs)-1] {
+		assert.LessOrEqual(t, len(log.Message), logr.MAX_MESSAGE_SIZE)
+		assert.True(t, utf8.ValidString(log.Message))
+		joined += log.Message
+	}
+	assert.Len(t, logs, 3, "the line is logged once the cap is reached")
+	assert.Equal(t, long, joined)
+	assert.Equal(t, "short", logs[len(logs)-1].Message)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestLogrtest(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "logrtest.log")
+
+	logger.Infow("user signed in", "user_id", 42)
+	logger.Error("connection refused")
+	logger.Warn(strings.Repeat("long ", 5000))
+	logger.Inc("requests", 1)
+	logger.Inc("requests", 2)
+	logger.Avg("latency", 10)
+	logger.Avg("latency", 20)
+	logger.Max("latency", 20)
+	logrtest.Flush(t, logger)
+
+	rec.AssertLogged(t, types.LevelInfo, "user signed in")
+	rec.AssertLogged(t, types.LevelError, "connection refused")
+	rec.AssertNotLogged(t, types.LevelInfo, "connection refused")
+	assert.True(t, rec.Logged(types.LevelWarn, strings.Repeat("long ", 5000)), "chunked logs are joined")
+	assert.Equal(t, types.KV("user_id", int64(42)), rec.Logs()[0].Fields)
+
+	assert.Equal(t, 3.0, rec.CounterValue("requests", logr.KIND_INC))
+	assert.Equal(t, 15.0, rec.CounterValue("latency", logr.KIND_AVG))
+	assert.Equal(t, 20.0, rec.CounterValue("latency", logr.KIND_MAX))
+	assert.Equal(t, 0.0, rec.CounterValue("missing", logr.KIND_INC))
+
+	logger.Inc("requests", 4)
+	logrtest.Flush(t, logger)
+	assert.Equal(t, 7.0, rec.CounterValue("requests", logr.KIND_INC))
+
+	rec.Reset()
+	assert.Empty(t, rec.Logs())
+	assert.Empty(t, rec.Counts())
+}
+
+func TestLogrtest_AssertLoggedFails(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "logrtest.log")
+	logger.Info("something else")
+
+	mock := &failRecorder{TB: t}
+	assert.False(t, rec.AssertLogged(mock, types.LevelInfo, "expected"))
+	assert.True(t, mock.failed)
+	assert.Contains(t, mock.msg, "something else")
+}
+
+type failRecorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *failRecorder) Errorf(format string, args ...interface{}) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
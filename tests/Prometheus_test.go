@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+)
+
+func scrape(t *testing.T, counter *logr.Counter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	counter.PrometheusHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, logr.PROMETHEUS_CONTENT_TYPE, rec.Header().Get("Content-Type"))
+	return rec.Body.String()
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	conf := &logr.Config{Sinks: []logr.Sink{logr.NewJsonSink(io.Discard)}, CounterInterval: time.Hour}
+	counter, _ := conf.NewCounter("prom.log")
+	defer counter.Close(context.Background())
+
+	counter.Inc("http.requests", 2, logr.Labels{"code": "200"})
+	counter.Inc("http.requests", 1, logr.Labels{"code": "500"})
+	counter.Avg("la", 1)
+	counter.Avg("la", 3)
+	counter.Per("mem", 25, 100)
+	counter.Max("conns", 7)
+	for i := 1; i <= 100; i++ {
+		counter.Hist("latency", float64(i))
+	}
+	done := counter.Time("query", time.Second)
+
+	body := scrape(t, counter)
+	assert.Contains(t, body, "# TYPE http_requests_total counter\n"+
+		`http_requests_total{code="200",logname="prom.log"} 2`+"\n"+
+		`http_requests_total{code="500",logname="prom.log"} 1`+"\n")
+	assert.Contains(t, body, "# TYPE la_avg gauge\n"+`la_avg{logname="prom.log"} 2`+"\n")
+	assert.Contains(t, body, `mem_per{logname="prom.log"} 25`)
+	assert.Contains(t, body, `conns_max{logname="prom.log"} 7`)
+	assert.Contains(t, body, "# TYPE latency summary\n")
+	assert.Contains(t, body, `latency_count{logname="prom.log"} 100`)
+	assert.Contains(t, body, `latency_sum{logname="prom.log"} 5050`)
+	assert.Contains(t, body, `latency{logname="prom.log",quantile="0.5"} 49.9`)
+
+	time.Sleep(10 * time.Millisecond)
+	done()
+	counter.Flush()
+	counter.Inc("http.requests", 4, logr.Labels{"code": "200"})
+	counter.Avg("la", 10)
+
+	body = scrape(t, counter)
+	assert.Contains(t, body, `http_requests_total{code="200",logname="prom.log"} 6`, "incs add up over the windows")
+	assert.Contains(t, body, `http_requests_total{code="500",logname="prom.log"} 1`)
+	assert.Contains(t, body, `la_avg{logname="prom.log"} 2`, "gauges show the last flushed window")
+	assert.Contains(t, body, "# TYPE query_seconds summary\n")
+	assert.Contains(t, body, `query_seconds_count{logname="prom.log"} 1`)
+	assert.NotContains(t, body, "query_avg")
+
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			assert.Regexp(t, `^[a-zA-Z_:][a-zA-Z0-9_:]*(\{.*\})? \S+$`, line)
+		}
+	}
+}
+
+func TestPrometheusHandler_Collisions(t *testing.T) {
+	conf := &logr.Config{Sinks: []logr.Sink{logr.NewJsonSink(io.Discard)}, CounterInterval: time.Hour}
+	counter, _ := conf.NewCounter("prom.log")
+	defer counter.Close(context.Background())
+
+	counter.Inc("jobs", 1, logr.Labels{"logname": "worker", "quantile": "q1", "a.b": "1", "a_b": "2"})
+	counter.Hist("latency", 1, logr.Labels{"quantile": "q1"})
+	counter.Inc("a.b", 1)
+	counter.Avg("a_b", 1)
+	counter.Inc("a_b", 2)
+
+	body := scrape(t, counter)
+	assert.Contains(t, body, `jobs_total{a_b="1",exported_a_b="2",exported_logname="worker",exported_quantile="q1",logname="prom.log"} 1`)
+	assert.Contains(t, body, `latency{exported_quantile="q1",logname="prom.log",quantile="0.5"} 0.99`)
+	assert.Contains(t, body, `a_b_total{logname="prom.log"} 1`+"\n", "the first keyname gets the name")
+	assert.Equal(t, 1, strings.Count(body, "# TYPE a_b_total "))
+	assert.Contains(t, body, `a_b_avg{logname="prom.log"} 1`, "the other names stay")
+	assert.Contains(t, body, `# collision: "a_b" skipped, a_b_total is taken by "a.b"`)
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/cipher"
+	"github.com/504dev/logr-go-client/receiver"
+	"github.com/504dev/logr-go-client/types"
+)
+
+const testPrivateKey = "MC0CAQACBQDIOyKzAgMBAAECBQCHaZwRAgMA0nkCAwDziwIDAL+xAgJMKwICGq0="
+
+func receiveLog(t *testing.T, r *receiver.Receiver) *types.Log {
+	t.Helper()
+	select {
+	case log := <-r.Logs:
+		return log
+	case err := <-r.Errors:
+		t.Fatalf("receive: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("receive: timeout")
+	}
+	return nil
+}
+
+func TestReceiver(t *testing.T) {
+	for _, mode := range []cipher.Mode{cipher.ModeCfb, cipher.ModeGcm} {
+		t.Run(string(mode), func(t *testing.T) {
+			rconf := receiver.Config{PrivateKey: testPrivateKey}
+			r, err := rconf.Listen("127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("listen: %v", err)
+			}
+			defer r.Close()
+
+			sink, err := logr.NewUdpSink(&logr.Config{
+				Udp:        r.Addr().String(),
+				PrivateKey: testPrivateKey,
+				Cipher:     mode,
+			})
+			if err != nil {
+				t.Fatalf("new sink: %v", err)
+			}
+			defer sink.Close()
+
+			long := strings.Repeat("0123456789", 3*logr.MAX_MESSAGE_SIZE/10)
+			chunks, err := sink.PushLog(&types.Log{Level: string(types.LevelInfo), Message: long})
+			assert.NoError(t, err)
+			assert.Greater(t, chunks, 1)
+			_, err = sink.PushLog(&types.Log{Level: string(types.LevelWarn), Message: "short"})
+			assert.NoError(t, err)
+
+			assert.Equal(t, long, receiveLog(t, r).Message)
+			assert.Equal(t, "short", receiveLog(t, r).Message)
+
+			count := &types.Count{Keyname: "requests"}
+			count.Inc(3)
+			_, err = sink.PushCount(count)
+			assert.NoError(t, err)
+			select {
+			case received := <-r.Counts:
+				assert.Equal(t, "requests", received.Keyname)
+				assert.Equal(t, 3.0, received.Metrics.Inc.Val)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("receive count: timeout")
+			}
+		})
+	}
+}
+
+func TestReceiver_FullChannelDrops(t *testing.T) {
+	rconf := receiver.Config{PrivateKey: testPrivateKey, BufferSize: 1}
+	r, err := rconf.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer r.Close()
+	sink, err := logr.NewUdpSink(&logr.Config{Udp: r.Addr().String(), PrivateKey: testPrivateKey})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	defer sink.Close()
+
+	// nobody reads Counts, the logs must keep coming anyway
+	for i := 0; i < 3; i++ {
+		_, err = sink.PushCount(&types.Count{Keyname: "unread"})
+		assert.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return r.Dropped() == 2 }, 2*time.Second, 10*time.Millisecond)
+	_, err = sink.PushLog(&types.Log{Message: "still here"})
+	assert.NoError(t, err)
+	for {
+		select {
+		case log := <-r.Logs:
+			assert.Equal(t, "still here", log.Message)
+			return
+		case err := <-r.Errors:
+			assert.ErrorIs(t, err, receiver.ErrBufferFull)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("receive: timeout")
+		}
+	}
+}
+
+// flakyConn fails its first read.
+type flakyConn struct {
+	net.PacketConn
+	failed bool
+}
+
+func (c *flakyConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	if !c.failed {
+		c.failed = true
+		return 0, nil, errors.New("transient read error")
+	}
+	return c.PacketConn.ReadFrom(b)
+}
+
+func TestReceiver_ReadErrorContinues(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	rconf := receiver.Config{PrivateKey: testPrivateKey}
+	r := rconf.Serve(&flakyConn{PacketConn: conn})
+	defer r.Close()
+
+	select {
+	case err := <-r.Errors:
+		assert.EqualError(t, err, "transient read error")
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no read error")
+	}
+	sink, err := logr.NewUdpSink(&logr.Config{Udp: r.Addr().String(), PrivateKey: testPrivateKey})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	defer sink.Close()
+	_, err = sink.PushLog(&types.Log{Message: "after the error"})
+	assert.NoError(t, err)
+	assert.Equal(t, "after the error", receiveLog(t, r).Message)
+}
+
+func chunkify(t *testing.T, message string) types.LogPackageChunks {
+	t.Helper()
+	lp := types.LogPackage{Log: &types.Log{Message: message}}
+	assert.NoError(t, lp.EncryptLog(testPrivateKey))
+	chunks, err := lp.Chunkify(1000, testPrivateKey)
+	assert.NoError(t, err)
+	return chunks
+}
+
+func TestReassembler_OutOfOrder(t *testing.T) {
+	message := strings.Repeat("abcdef", 1000)
+	chunks := chunkify(t, message)
+	rand.Shuffle(len(chunks), func(i, j int) { chunks[i], chunks[j] = chunks[j], chunks[i] })
+
+	ra := receiver.NewReassembler(testPrivateKey, time.Second)
+	var joined *types.LogPackage
+	for i, chunk := range chunks {
+		res, err := ra.Add(chunk)
+		assert.NoError(t, err)
+		if i < len(chunks)-1 {
+			assert.Nil(t, res)
+			_, err = ra.Add(chunk)
+			assert.NoError(t, err, "duplicates are ignored")
+		} else {
+			joined = res
+		}
+	}
+	if assert.NotNil(t, joined) {
+		log, _, err := (&receiver.Config{PrivateKey: testPrivateKey}).Decode(joined)
+		assert.NoError(t, err)
+		assert.Equal(t, message, log.Message)
+	}
+	assert.Equal(t, 0, ra.Pending())
+}
+
+func TestReassembler_Rejects(t *testing.T) {
+	chunks := chunkify(t, strings.Repeat("abcdef", 1000))
+	ra := receiver.NewReassembler(testPrivateKey, time.Second)
+
+	forged := *chunks[0]
+	forged.Chunk = &types.ChunkInfo{Uid: forged.Chunk.Uid, Ts: forged.Chunk.Ts, I: 1, N: forged.Chunk.N}
+	_, err := ra.Add(&forged)
+	assert.ErrorIs(t, err, receiver.ErrBadSignature)
+
+	other := receiver.NewReassembler("b3RoZXIga2V5", time.Second)
+	_, err = other.Add(chunks[0])
+	assert.ErrorIs(t, err, receiver.ErrBadSignature)
+
+	_, err = ra.Add(chunks[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ra.Pending())
+	assert.Equal(t, 0, ra.Expire(time.Now()))
+	assert.Equal(t, 1, ra.Expire(time.Now().Add(2*time.Second)))
+	assert.Equal(t, 0, ra.Pending())
+}
+
+func TestReceiver_NoKey(t *testing.T) {
+	rconf := receiver.Config{PlainOnly: true}
+	r, err := rconf.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer r.Close()
+
+	plain, err := logr.NewUdpSink(&logr.Config{Udp: r.Addr().String(), PrivateKey: testPrivateKey, NoCipher: true})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	defer plain.Close()
+	_, err = plain.PushLog(&types.Log{Level: string(types.LevelInfo), Message: "plain"})
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", receiveLog(t, r).Message, "NoCipher logs are read without a key")
+
+	ciphered, err := logr.NewUdpSink(&logr.Config{Udp: r.Addr().String(), PrivateKey: testPrivateKey})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	defer ciphered.Close()
+	_, err = ciphered.PushLog(&types.Log{Level: string(types.LevelInfo), Message: "secret"})
+	assert.NoError(t, err)
+	select {
+	case err := <-r.Errors:
+		assert.ErrorIs(t, err, receiver.ErrNoKey)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("receive error: timeout")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	logr "github.com/504dev/logr-go-client"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestTransport_NotConnected(t *testing.T) {
+	conf := logr.Config{Udp: "logr.invalid:7776", NoCipher: true}
+	logger, err := conf.NewLogger("reconnect-test.log")
+	assert.Error(t, err)
+	defer logger.Close()
+
+	assert.Equal(t, logr.ConnDisconnected, logger.ConnState())
+	_, err = logger.PushLog(&types.Log{Message: "lost"})
+	assert.ErrorIs(t, err, logr.ErrNotConnected)
+}
+
+// TestTransport_Reconnect starts the collector after the logger and expects
+// a retried push to get through once it is up.
+func TestTransport_Reconnect(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	conf := logr.Config{
+		Grpc:            addr,
+		NoCipher:        true,
+		RetryAttempts:   20,
+		RetryBackoff:    50 * time.Millisecond,
+		RetryMaxBackoff: 500 * time.Millisecond,
+	}
+	logger, err := conf.NewLogger("reconnect-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	assert.NotEqual(t, logr.ConnConnected, logger.ConnState())
+
+	collector := &grpcCollector{}
+	lis, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLogRpcServer(server, collector)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	_, err = logger.PushLog(&types.Log{Message: "delivered"})
+	assert.NoError(t, err)
+	assert.Equal(t, logr.ConnConnected, logger.ConnState())
+
+	packages, _ := collector.Received()
+	if assert.Len(t, packages, 1) {
+		assert.Equal(t, "delivered", packages[0].Log.Message)
+	}
+}
+
+func TestTransport_NoServer(t *testing.T) {
+	conf := logr.Config{NoCipher: true}
+	logger, err := conf.NewLogger("no-server.log")
+	assert.NoError(t, err)
+	defer logger.Close()
+	logger.Console = false
+
+	n, err := logger.PushLog(&types.Log{Message: "nowhere"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestTransport_ConnFields(t *testing.T) {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen udp: %v", err)
+	}
+	defer udp.Close()
+	logger, err := (&logr.Config{Udp: udp.LocalAddr().String(), NoCipher: true}).NewLogger("conn-fields.log")
+	assert.NoError(t, err)
+	defer logger.Close()
+	assert.NotNil(t, logger.Transport.Conn)
+	assert.Equal(t, logger.Transport.Conn, logger.UdpConn())
+	assert.Equal(t, udp.LocalAddr().String(), logger.RemoteAddr().String())
+	assert.Nil(t, logger.GrpcConn)
+
+	logger, err = (&logr.Config{Grpc: "127.0.0.1:1", NoCipher: true}).NewLogger("conn-fields.log")
+	assert.NoError(t, err)
+	defer logger.Close()
+	assert.NotNil(t, logger.GrpcConn)
+	assert.NotNil(t, logger.GrpcClient)
+	assert.Equal(t, logger.GrpcConn, logger.GrpcClientConn())
+	assert.Nil(t, logger.Transport.Conn)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestLogger_Recover(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "recover.log")
+	logger.Body = "{message}"
+
+	var line string
+	func() {
+		defer logger.Recover()
+		line = nextLine()
+		panic("boom")
+	}()
+
+	logs := rec.Logs()
+	assert.Len(t, logs, 1)
+	assert.Equal(t, string(types.LevelCrit), logs[0].Level)
+	assert.True(t, strings.HasPrefix(logs[0].Message, "panic: boom\n\ngoroutine "), logs[0].Message)
+	assert.Contains(t, logs[0].Message, "TestLogger_Recover")
+	assert.Equal(t, line, logs[0].Initiator)
+	assert.Equal(t, `panic=boom`, logs[0].Fields.String())
+}
+
+func TestLogger_Recover_RuntimeError(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "recover.log")
+
+	var line string
+	func() {
+		defer logger.Recover()
+		var m map[string]int
+		line = nextLine()
+		m["x"] = 1
+	}()
+
+	assert.True(t, rec.Logged(types.LevelCrit, "assignment to entry in nil map"))
+	assert.Equal(t, line, lastInitiator(rec))
+}
+
+func TestLogger_Recover_Repanic(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "recover.log")
+	logger.Repanic = true
+	logger.Inc("before.crash", 1)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		defer logger.Recover()
+		panic("boom")
+	})
+	assert.True(t, rec.Logged(types.LevelEmerg, "panic: boom"))
+	assert.Equal(t, 1.0, rec.CounterValue("before.crash", logr.KIND_INC), "the counts are pushed before crashing")
+}
+
+func TestLogger_Go(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "recover.log")
+
+	done := make(chan struct{})
+	logger.Go(func() {
+		defer close(done)
+		panic("in goroutine")
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the goroutine did not end")
+	}
+	assert.Eventually(t, func() bool {
+		return rec.Logged(types.LevelCrit, "panic: in goroutine")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// delaySink takes a while to push a log, like a slow network.
+type delaySink struct {
+	logr.Sink
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *delaySink) PushLog(log *types.Log) (int, error) {
+	time.Sleep(50 * time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, log.Message)
+	return 0, nil
+}
+
+func TestLogger_Recover_FlushesAsync(t *testing.T) {
+	sink := &delaySink{Sink: logr.NewJsonSink(io.Discard)}
+	conf := &logr.Config{Sinks: []logr.Sink{sink}, AsyncQueueSize: 10, NoCipher: true}
+	logger, _ := conf.NewLogger("recover-async.log")
+	logger.Console = false
+	logger.Body = "{message}"
+	defer logger.Close()
+
+	func() {
+		defer logger.Recover()
+		panic("boom")
+	}()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Len(t, sink.messages, 1, "Recover returns once the log is sent")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/types"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while sinks write to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestConfigSinks(t *testing.T) {
+	var logs, all syncBuffer
+	conf := logr.Config{
+		Sinks: []logr.Sink{
+			logr.NewJsonSink(&logs),
+			logr.MultiSink{logr.NewJsonSink(&all)},
+		},
+	}
+	logger, err := conf.NewLogger("sink-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	logger.Infow("to the sinks", "user_id", 7)
+	logger.Inc("requests", 1)
+	logger.Counter.Flush()
+	assert.NoError(t, logger.Flush(context.Background()))
+
+	assert.Eventually(t, func() bool {
+		return strings.Count(all.String(), "\n") == 2
+	}, time.Second, 10*time.Millisecond)
+
+	var log types.Log
+	line, _, _ := strings.Cut(logs.String(), "\n")
+	assert.NoError(t, gojson.Unmarshal([]byte(line), &log))
+	assert.Equal(t, "sink-test.log", log.Logname)
+	assert.Contains(t, log.Message, "to the sinks")
+	assert.Equal(t, types.Fields{{Key: "user_id", Value: int64(7)}}, log.Fields)
+	assert.Contains(t, all.String(), `"keyname":"requests"`)
+}
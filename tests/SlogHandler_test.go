@@ -0,0 +1,84 @@
+//go:build go1.21
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestSlogHandler(t *testing.T) {
+	collector := newUdpCollector(t)
+	conf := logr.Config{Udp: collector.Addr(), NoCipher: true}
+	logger, err := conf.NewLogger("slog-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+	logger.Level = types.LevelInfo
+
+	sl := logger.Slog().With("service", "api").WithGroup("req")
+
+	assert.False(t, sl.Enabled(context.Background(), slog.LevelDebug))
+	sl.Debug("dropped")
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := slog.NewRecord(ts, slog.LevelWarn, "slow request", 0)
+	record.AddAttrs(
+		slog.String("path", "/api"),
+		slog.Group("db", slog.Int("rows", 3), slog.Duration("took", time.Second)),
+	)
+	assert.NoError(t, sl.Handler().Handle(context.Background(), record))
+
+	log := collector.Log()
+	assert.Equal(t, "slog-test.log", log.Logname)
+	assert.Equal(t, string(types.LevelWarn), log.Level)
+	assert.Equal(t, ts.UnixNano(), log.Timestamp)
+	assert.Contains(t, log.Message, "slow request")
+	assert.Equal(t, types.Fields{
+		{Key: "service", Value: "api"},
+		{Key: "req.path", Value: "/api"},
+		{Key: "req.db.rows", Value: int64(3)},
+		{Key: "req.db.took", Value: "1s"},
+	}, log.Fields)
+
+	sl.Log(context.Background(), logr.SlogLevelCrit, "disk is gone")
+	assert.Equal(t, string(types.LevelCrit), collector.Log().Level)
+}
+
+func TestSlogLevel(t *testing.T) {
+	assert.Equal(t, types.Level(types.LevelDebug), logr.SlogLevel(slog.LevelDebug))
+	assert.Equal(t, types.Level(types.LevelInfo), logr.SlogLevel(slog.LevelInfo))
+	assert.Equal(t, types.Level(types.LevelNotice), logr.SlogLevel(logr.SlogLevelNotice))
+	assert.Equal(t, types.Level(types.LevelWarn), logr.SlogLevel(slog.LevelWarn))
+	assert.Equal(t, types.Level(types.LevelError), logr.SlogLevel(slog.LevelError))
+	assert.Equal(t, types.Level(types.LevelAlert), logr.SlogLevel(logr.SlogLevelAlert))
+	assert.Equal(t, types.Level(types.LevelEmerg), logr.SlogLevel(logr.SlogLevelEmerg+4))
+}
+
+func TestSlogHandler_Caller(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "slog-caller.log")
+	slogger := logger.Slog()
+
+	line := nextLine()
+	slogger.Info("from slog")
+	assert.Equal(t, line, lastInitiator(rec))
+}
+
+func TestSlogHandler_Context(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "slog-ctx.log")
+	slogger := logger.Slog()
+
+	ctx := logr.NewContext(context.Background(), types.KV("request_id", "r1"))
+	slogger.InfoContext(ctx, "from slog", "k", "v")
+	assert.Equal(t, `request_id=r1 k=v`, rec.Logs()[0].Fields.String())
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	logr "github.com/504dev/logr-go-client"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/spool"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func spoolMessages(t *testing.T, sp *spool.Spool, failAt int) []string {
+	var messages []string
+	err := sp.Replay(func(rec spool.Record) error {
+		if len(messages) == failAt {
+			return errors.New("collector is down")
+		}
+		messages = append(messages, rec.Log.Message)
+		return nil
+	})
+	if failAt < 0 {
+		assert.NoError(t, err)
+	}
+	return messages
+}
+
+func TestSpool_Replay(t *testing.T) {
+	dir := t.TempDir()
+	sp, err := spool.Open(dir, 200, 0)
+	if err != nil {
+		t.Fatalf("open spool: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, sp.Append(spool.Record{Log: &types.Log{Message: fmt.Sprint(i), Timestamp: int64(i)}}))
+	}
+	assert.NoError(t, sp.Append(spool.Record{Count: &types.Count{Keyname: "count"}}))
+	assert.NoError(t, sp.Close())
+
+	// a reopened spool picks up the segments left on disk
+	sp, err = spool.Open(dir, 200, 0)
+	if err != nil {
+		t.Fatalf("reopen spool: %v", err)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3"}, spoolMessages(t, sp, 4))
+
+	var logs []*types.Log
+	var counts []*types.Count
+	assert.NoError(t, sp.Replay(func(rec spool.Record) error {
+		if rec.Log != nil {
+			logs = append(logs, rec.Log)
+		} else {
+			counts = append(counts, rec.Count)
+		}
+		return nil
+	}))
+	assert.Len(t, logs, 6)
+	assert.Equal(t, "4", logs[0].Message, "replay resumes at the failed record")
+	assert.Equal(t, int64(9), logs[5].Timestamp)
+	assert.Len(t, counts, 1)
+	assert.True(t, sp.Empty())
+}
+
+func TestSpool_MaxSize(t *testing.T) {
+	sp, err := spool.Open(t.TempDir(), 100, 300)
+	if err != nil {
+		t.Fatalf("open spool: %v", err)
+	}
+	defer sp.Close()
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, sp.Append(spool.Record{Log: &types.Log{Message: fmt.Sprint(i)}}))
+	}
+	assert.LessOrEqual(t, sp.Size(), int64(300))
+
+	messages := spoolMessages(t, sp, -1)
+	assert.Equal(t, "29", messages[len(messages)-1])
+	assert.NotEqual(t, "0", messages[0], "the oldest segments are dropped first")
+}
+
+func TestTransport_Spool(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	conf := logr.Config{Grpc: addr, NoCipher: true, SpoolDir: t.TempDir()}
+	logger, err := conf.NewLogger("spool-test.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano()
+	_, err = logger.PushLog(&types.Log{Message: "spooled", Timestamp: ts})
+	assert.NoError(t, err)
+	assert.Greater(t, logger.Spooled(), int64(0))
+
+	collector := &grpcCollector{}
+	lis, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLogRpcServer(server, collector)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	var received []*pb.LogRpcPackage
+	assert.Eventually(t, func() bool {
+		logger.PushLog(&types.Log{Message: "live"})
+		received, _ = collector.Received()
+		for _, p := range received {
+			if p.Log.Message == "spooled" {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	for _, p := range received {
+		if p.Log.Message == "spooled" {
+			assert.Equal(t, ts, p.Log.Timestamp, "spooled logs keep their timestamp")
+		}
+	}
+	assert.Eventually(t, func() bool { return logger.Spooled() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+// refusingCollector refuses the logs with the "poison" message for good.
+type refusingCollector struct {
+	*grpcCollector
+}
+
+func (c refusingCollector) Push(ctx context.Context, p *pb.LogRpcPackage) (*pb.Response, error) {
+	if p.Log != nil && p.Log.Message == "poison" {
+		return nil, status.Error(codes.InvalidArgument, "refused")
+	}
+	return c.grpcCollector.Push(ctx, p)
+}
+
+func TestTransport_SpoolPoisonAndOrder(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	conf := logr.Config{Grpc: addr, NoCipher: true, SpoolDir: t.TempDir()}
+	logger, err := conf.NewLogger("spool-poison.log")
+	if err != nil {
+		t.Fatalf("new logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Console = false
+
+	for _, msg := range []string{"first", "poison", "second"} {
+		_, err = logger.PushLog(&types.Log{Message: msg})
+		assert.NoError(t, err)
+	}
+
+	collector := refusingCollector{&grpcCollector{}}
+	lis, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterLogRpcServer(server, collector)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	live := 0
+	assert.Eventually(t, func() bool {
+		logger.PushLog(&types.Log{Message: fmt.Sprint("live ", live)})
+		live++
+		return logger.Spooled() == 0
+	}, 10*time.Second, 50*time.Millisecond)
+
+	received, _ := collector.Received()
+	var messages []string
+	for _, p := range received {
+		messages = append(messages, p.Log.Message)
+	}
+	assert.Equal(t, []string{"first", "second"}, messages[:2], "the poison record is dropped, the spooled ones go before the live ones")
+	for i, msg := range messages[2:] {
+		assert.Equal(t, fmt.Sprint("live ", i), msg)
+	}
+
+	// a refused live record is not spooled
+	_, err = logger.PushLog(&types.Log{Message: "poison"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int64(0), logger.Spooled())
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	logr "github.com/504dev/logr-go-client"
+	"github.com/504dev/logr-go-client/logrtest"
+	"github.com/504dev/logr-go-client/types"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "stdlog.log")
+
+	var before bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&before)
+
+	restore := logr.RedirectStdLog(logger, types.LevelWarn)
+	log.Println("from the std logger")
+	log.Printf("ERROR: failed")
+	restore()
+	log.Println("back to the buffer")
+
+	logs := rec.Logs()
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, string(types.LevelWarn), logs[0].Level)
+		assert.Equal(t, "from the std logger", logs[0].Message)
+		assert.True(t, strings.HasPrefix(logs[0].Initiator, "StdLog_test.go:"), logs[0].Initiator)
+		assert.Equal(t, string(types.LevelError), logs[1].Level)
+		assert.Equal(t, "failed", logs[1].Message)
+	}
+	assert.Contains(t, before.String(), "back to the buffer")
+	assert.NotContains(t, before.String(), "from the std logger")
+}
+
+func TestLogger_StdLogger(t *testing.T) {
+	logger, rec := logrtest.NewLogger(t, "stdlog.log")
+	std := logger.StdLogger(types.LevelError)
+
+	std.Printf("http: TLS handshake error from %s", "127.0.0.1")
+
+	logs := rec.Logs()
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, string(types.LevelError), logs[0].Level)
+		assert.Equal(t, "http: TLS handshake error from 127.0.0.1", logs[0].Message)
+		assert.True(t, strings.HasPrefix(logs[0].Initiator, "StdLog_test.go:"), logs[0].Initiator)
+	}
+}
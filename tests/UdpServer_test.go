@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	gojson "github.com/goccy/go-json"
+
+	"github.com/504dev/logr-go-client/types"
+)
+
+// udpCollector is a bare UDP socket standing in for the logr server.
+// It only understands unencrypted logs that fit into a single datagram.
+type udpCollector struct {
+	t    *testing.T
+	conn net.PacketConn
+}
+
+func newUdpCollector(t *testing.T) *udpCollector {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen udp: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &udpCollector{t: t, conn: conn}
+}
+
+func (c *udpCollector) Addr() string {
+	return c.conn.LocalAddr().String()
+}
+
+func (c *udpCollector) Log() *types.Log {
+	c.t.Helper()
+	buf := make([]byte, 65536)
+	c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := c.conn.ReadFrom(buf)
+	if err != nil {
+		c.t.Fatalf("read udp: %v", err)
+	}
+	lp := types.LogPackage{}
+	if err = gojson.Unmarshal(buf[:n], &lp); err != nil {
+		c.t.Fatalf("unmarshal package: %v", err)
+	}
+	if err = lp.DeserializeLog(); err != nil {
+		c.t.Fatalf("deserialize log: %v", err)
+	}
+	return lp.Log
+}
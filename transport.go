@@ -3,135 +3,296 @@ package logr_go_client
 import (
 	"context"
 	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	"github.com/504dev/logr-go-client/spool"
 	"github.com/504dev/logr-go-client/types"
-	gojson "github.com/goccy/go-json"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"log"
 	"net"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+const DEFAULT_SPOOL_REPLAY_INTERVAL = 5 * time.Second
+
+// Transport delivers records to the logr server configured by Config.Udp or
+// Config.Grpc, to Config.Destinations and to Config.Sinks. Records the server
+// could not get are kept in the spool, the async queue sits in front of everything.
 type Transport struct {
 	*Config
+	// Deprecated: the socket of the UDP logr server at Connect, it is
+	// replaced when it breaks. Use UdpConn.
 	net.Conn
-	GrpcConn   *grpc.ClientConn
-	GrpcClient pb.LogRpcClient
+	// Deprecated: use GrpcClientConn.
+	GrpcConn *grpc.ClientConn
+	// Deprecated: use GrpcClientConn.
+	GrpcClient   pb.LogRpcClient
+	name         string // subdirectory of Config.SpoolDir
+	sink         Sink   // UdpSink or GrpcSink, nil if neither address is set
+	destinations MultiSink
+	queue        *asyncQueue
+	spool        *spool.Spool
+	replay       chan struct{}
+	replayDone   chan struct{}
+	replayFailed int32 // set while the last replay failed
+	closed       chan struct{}
 }
 
+// Connect dials the logr server. If that fails the error is returned and
+// the transport keeps reconnecting in the background.
 func (tp *Transport) Connect(conf *Config) error {
 	var err error
+	tp.Config = conf
+	tp.closed = make(chan struct{})
 	if conf.Udp != "" {
-		tp.Conn, err = net.Dial("udp", conf.Udp)
-	} else {
-		tp.GrpcConn, err = grpc.Dial(conf.Grpc, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		tp.sink, err = NewUdpSink(conf)
+	} else if conf.Grpc != "" {
+		var gs *GrpcSink
+		gs, err = NewGrpcSink(conf)
+		gs.fail = tp.spoolItems
+		tp.sink = gs
+	}
+	tp.Conn = tp.UdpConn()
+	if tp.GrpcConn = tp.GrpcClientConn(); tp.GrpcConn != nil {
 		tp.GrpcClient = pb.NewLogRpcClient(tp.GrpcConn)
 	}
-	if err != nil {
-		tp.Conn = nil
-		tp.GrpcConn = nil
-		tp.GrpcClient = nil
+	if tp.sink != nil && conf.SpoolDir != "" && tp.spool == nil {
+		dir := conf.SpoolDir
+		if tp.name != "" {
+			dir = filepath.Join(dir, strings.ReplaceAll(tp.name, string(filepath.Separator), "_"))
+		}
+		sp, spoolErr := spool.Open(dir, conf.SpoolSegmentSize, conf.SpoolMaxSize)
+		if spoolErr != nil && err == nil {
+			err = spoolErr
+		}
+		if spoolErr == nil {
+			tp.spool = sp
+			tp.replay = make(chan struct{}, 1)
+			tp.replayDone = make(chan struct{})
+			go tp.replayLoop()
+		}
+	}
+	if len(conf.Destinations) > 0 && tp.destinations == nil {
+		var destErr error
+		tp.destinations, destErr = tp.connectDestinations(conf)
+		if err == nil {
+			err = destErr
+		}
+	}
+	if conf.AsyncQueueSize > 0 && tp.queue == nil {
+		tp.queue = newAsyncQueue(conf.AsyncQueueSize, conf.AsyncOverflow, tp.send)
 	}
-	tp.Config = conf
 	return err
 }
 
-func (tp *Transport) Close() error {
-	if tp.GrpcConn != nil {
-		return tp.GrpcConn.Close()
-	} else if tp.Conn != nil {
-		return tp.Conn.Close()
-	} else {
-		return nil
+// UdpConn returns the current socket of the UDP logr server, nil if it is not connected.
+func (tp *Transport) UdpConn() net.Conn {
+	if us, ok := tp.sink.(*UdpSink); ok {
+		return us.conn.udpConn()
 	}
+	return nil
 }
 
-func (tp *Transport) pushGrpc(lp *types.LogPackage) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-	//fmt.Println(string(lp.ProtoBytes()), len(lp.ProtoBytes()))
-	req := lp.Proto()
-	_, err := tp.GrpcClient.Push(ctx, req)
-	return err
-}
-
-func (tp *Transport) PushLog(log *types.Log) (int, error) {
-	if tp.Conn == nil && tp.GrpcConn == nil {
-		return 0, nil
+// GrpcClientConn returns the client connection of the gRPC logr server, nil if there is none.
+func (tp *Transport) GrpcClientConn() *grpc.ClientConn {
+	if gs, ok := tp.sink.(*GrpcSink); ok {
+		return gs.conn.grpcClientConn()
 	}
+	return nil
+}
 
-	lp := types.LogPackage{
-		DashId:    tp.Config.DashId,
-		PublicKey: tp.Config.PublicKey,
-		Log:       log,
+// ConnState reports whether the logr server is currently reachable.
+func (tp *Transport) ConnState() ConnState {
+	if cs, ok := tp.sink.(interface{ ConnState() ConnState }); ok {
+		return cs.ConnState()
 	}
+	return ConnDisconnected
+}
 
-	if tp.Config.NoCipher == false {
-		err := lp.EncryptLog(tp.Config.PrivateKey)
-		if err != nil {
-			return 0, err
+// Flush waits until the records queued in async mode are sent and flushes the sinks.
+func (tp *Transport) Flush(ctx context.Context) error {
+	if tp.queue != nil {
+		if err := tp.queue.flush(ctx); err != nil {
+			return err
 		}
 	}
+	return tp.sinks().Flush(ctx)
+}
 
-	if tp.GrpcConn != nil {
-		return 1, tp.pushGrpc(&lp)
+// Dropped returns the number of records discarded by the async queue overflow policy.
+func (tp *Transport) Dropped() uint64 {
+	if tp.queue == nil {
+		return 0
 	}
+	return tp.queue.Dropped()
+}
 
-	if tp.Config.NoCipher == true {
-		err := lp.SerializeLog()
-		if err != nil {
-			return 0, err
+// Close sends what is left and closes the connection to the logr server.
+// Config.Sinks are flushed, but left open: they belong to the caller.
+func (tp *Transport) Close() error {
+	if s, ok := tp.sink.(interface{ shutdown() }); ok {
+		// what is still queued gets one attempt, retrying would hold up the exit
+		s.shutdown()
+	}
+	if tp.queue != nil {
+		tp.queue.close()
+	}
+	if tp.Config != nil {
+		if err := MultiSink(tp.Config.Sinks).Flush(context.Background()); err != nil {
+			log.Println(err)
 		}
 	}
-
-	chunks, err := lp.Chunkify(MAX_MESSAGE_SIZE, tp.Config.PrivateKey)
-	if err != nil {
-		return 0, err
+	err := tp.destinations.Close()
+	if tp.sink == nil {
+		return err
 	}
+	if sinkErr := tp.sink.Close(); err == nil {
+		err = sinkErr
+	}
+	if tp.spool != nil {
+		select {
+		case <-tp.closed:
+		default:
+			close(tp.closed)
+		}
+		<-tp.replayDone
+		if spoolErr := tp.spool.Close(); err == nil {
+			err = spoolErr
+		}
+	}
+	return err
+}
 
-	messages, err := chunks.Marshal()
-	if err != nil {
-		return 0, err
+// Spooled returns the number of bytes waiting in the spool for the logr server to come back.
+func (tp *Transport) Spooled() int64 {
+	if tp.spool == nil {
+		return 0
 	}
+	return tp.spool.Size()
+}
 
-	for i, msg := range messages {
-		_, err = tp.Conn.Write(msg)
-		//fmt.Println(err, len(chunk))
-		if err != nil {
-			return i, err
-		}
+func (tp *Transport) sinks() MultiSink {
+	res := MultiSink{}
+	if tp.sink != nil {
+		res = append(res, tp.sink)
 	}
+	res = append(res, tp.destinations...)
+	if tp.Config != nil {
+		res = append(res, tp.Config.Sinks...)
+	}
+	return res
+}
 
-	return len(chunks), nil
+func (tp *Transport) send(item queueItem) error {
+	_, err := tp.push(item)
+	return err
 }
 
-func (tp *Transport) PushCount(count *types.Count) (int, error) {
-	if tp.Conn == nil && tp.GrpcConn == nil {
-		return 0, nil
+// PushLog sends the log, or only queues it when the transport is in async mode.
+func (tp *Transport) PushLog(log *types.Log) (int, error) {
+	if tp.queue != nil {
+		return 0, tp.queue.push(queueItem{log: log})
 	}
-	lp := types.LogPackage{
-		DashId:    tp.Config.DashId,
-		PublicKey: tp.Config.PublicKey,
-		Count:     count,
+	return tp.push(queueItem{log: log})
+}
+
+func (tp *Transport) PushCount(count *types.Count) (int, error) {
+	if tp.queue != nil {
+		return 0, tp.queue.push(queueItem{count: count})
 	}
+	return tp.push(queueItem{count: count})
+}
 
-	if !tp.Config.NoCipher {
-		err := lp.EncryptCount(tp.Config.PrivateKey)
-		if err != nil {
-			return 0, err
+func (tp *Transport) push(item queueItem) (n int, err error) {
+	others := tp.destinations
+	if tp.Config != nil {
+		others = append(others[:len(others):len(others)], tp.Config.Sinks...)
+	}
+	for _, sink := range others {
+		if _, sinkErr := item.pushTo(sink); err == nil {
+			err = sinkErr
 		}
 	}
+	if tp.sink == nil {
+		return len(others), err
+	}
+	var sinkErr error
+	if tp.spool != nil && !tp.spool.Empty() {
+		// the spooled records go first, the new ones queue up behind them
+		sinkErr = tp.spool.Append(spool.Record{Log: item.log, Count: item.count})
+		if atomic.LoadInt32(&tp.replayFailed) == 0 || tp.ConnState() == ConnConnected {
+			tp.nudgeReplay()
+		}
+	} else if n, sinkErr = item.pushTo(tp.sink); sinkErr != nil {
+		sinkErr = tp.spoolItems([]queueItem{item}, sinkErr)
+	}
+	if err == nil {
+		err = sinkErr
+	}
+	return n, err
+}
 
-	if tp.GrpcConn != nil {
-		return 0, tp.pushGrpc(&lp)
+func (tp *Transport) nudgeReplay() {
+	select {
+	case tp.replay <- struct{}{}:
+	default:
 	}
+}
 
-	msg, err := gojson.Marshal(lp)
-	if err != nil {
-		return 0, err
+// spoolItems puts the records the logr server did not get into the spool,
+// unless it refused them: they would fail the same way on every replay.
+func (tp *Transport) spoolItems(items []queueItem, err error) error {
+	if tp.spool == nil || !retryable(err) {
+		return err
+	}
+	for _, item := range items {
+		if spoolErr := tp.spool.Append(spool.Record{Log: item.log, Count: item.count}); spoolErr != nil {
+			return spoolErr
+		}
 	}
-	_, err = tp.Conn.Write(msg)
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// replayLoop sends the spooled records once the logr server is reachable again.
+// While the spool is not empty the pushes go to the spool and nudge the replay,
+// so the records reach the server in order; after a failed replay they nudge
+// it only once the connection is up again, the ticker retries meanwhile.
+func (tp *Transport) replayLoop() {
+	defer close(tp.replayDone)
+	ticker := time.NewTicker(DEFAULT_SPOOL_REPLAY_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tp.closed:
+			return
+		case <-ticker.C:
+		case <-tp.replay:
+		}
+		if tp.spool.Empty() {
+			continue
+		}
+		err := tp.spool.Replay(func(rec spool.Record) error {
+			item := queueItem{log: rec.Log, count: rec.Count}
+			var err error
+			if gs, ok := tp.sink.(*GrpcSink); ok {
+				// replayed records skip batching, so a failure stops the replay
+				err = gs.push(item, false)
+			} else {
+				_, err = item.pushTo(tp.sink)
+			}
+			if err != nil && !retryable(err) {
+				// the server refused the record, keeping it would block the ones behind it
+				log.Println("spooled record dropped:", err)
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			// the pushes stop nudging until the server is back, the ticker retries
+			atomic.StoreInt32(&tp.replayFailed, 1)
+		} else {
+			atomic.StoreInt32(&tp.replayFailed, 0)
+		}
 	}
-	return len(msg), nil
 }
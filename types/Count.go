@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/504dev/logr-go-client/cipher"
+	"math"
 	"sync"
 	"time"
 )
@@ -14,6 +15,7 @@ type Count struct {
 	Version   string `db:"version"   json:"version,omitempty"`
 	Logname   string `db:"logname"   json:"logname,omitempty"`
 	Keyname   string `db:"keyname"   json:"keyname"`
+	Labels    Labels `db:"labels"    json:"labels,omitempty"`
 	Metrics   `json:"metrics"`
 }
 
@@ -24,6 +26,7 @@ type Metrics struct {
 	*Avg
 	*Per
 	*Time
+	*Hist
 }
 
 // for logr usage
@@ -47,21 +50,75 @@ func (m Metrics) ToMap() map[string]interface{} {
 	if m.Time != nil {
 		res["time"] = m.Time.Value()
 	}
+	if m.Hist != nil {
+		res["hist"] = m.Hist.Percentiles()
+	}
 	return res
 }
 
+// Merge adds other, the metrics of a later window, to m.
+func (m *Metrics) Merge(other Metrics) {
+	if other.Inc != nil {
+		if m.Inc == nil {
+			m.Inc = &Inc{}
+		}
+		m.Inc.Val += other.Inc.Val
+	}
+	if other.Max != nil && (m.Max == nil || other.Max.Val > m.Max.Val) {
+		m.Max = &Max{Val: other.Max.Val}
+	}
+	if other.Min != nil && (m.Min == nil || other.Min.Val < m.Min.Val) {
+		m.Min = &Min{Val: other.Min.Val}
+	}
+	if other.Avg != nil {
+		if m.Avg == nil {
+			m.Avg = &Avg{}
+		}
+		m.Avg.Sum += other.Avg.Sum
+		m.Avg.Num += other.Avg.Num
+	}
+	if other.Per != nil {
+		if m.Per == nil {
+			m.Per = &Per{}
+		}
+		m.Per.Taken += other.Per.Taken
+		m.Per.Total += other.Per.Total
+	}
+	if other.Time != nil {
+		m.Time = &Time{Duration: other.Time.Duration}
+	}
+	if other.Hist != nil {
+		if m.Hist == nil {
+			m.Hist = &Hist{}
+		}
+		m.Hist.Merge(other.Hist)
+	}
+}
+
 func (c *Count) Decrypt(cipherData []byte, priv string) error {
 	c.RLock()
 	defer c.RUnlock()
 	return cipher.DecodeAesJson(cipherData, priv, c)
 }
 
+func (c *Count) DecryptMode(cipherData []byte, priv string, mode cipher.Mode) error {
+	c.RLock()
+	defer c.RUnlock()
+	return cipher.DecodeAesJsonMode(cipherData, priv, c, mode)
+}
+
 func (c *Count) Encrypt(priv string) ([]byte, error) {
 	c.RLock()
 	defer c.RUnlock()
 	return cipher.EncryptAesJson(c, priv)
 }
 
+func (c *Count) EncryptMode(priv string, mode cipher.Mode) ([]byte, error) {
+	c.RLock()
+	defer c.RUnlock()
+	return cipher.EncryptAesJsonMode(c, priv, mode)
+}
+
 func (c *Count) now() {
 	c.Timestamp = time.Now().Unix()
 }
@@ -136,6 +193,21 @@ func (c *Count) Per(taken float64, total float64) *Count {
 	return c
 }
 
+// Hist ignores NaN and infinite values, see Hist.Add.
+func (c *Count) Hist(num float64) *Count {
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return c
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.Metrics.Hist == nil {
+		c.Metrics.Hist = &Hist{}
+	}
+	c.Metrics.Hist.Add(num)
+	c.now()
+	return c
+}
+
 func (c *Count) Time(duration time.Duration) func() time.Duration {
 	c.Lock()
 	defer c.Unlock()
@@ -157,6 +229,18 @@ func (c *Count) Time(duration time.Duration) func() time.Duration {
 	}
 }
 
+// TimeElapsed records a measured elapsed time the way Time does.
+func (c *Count) TimeElapsed(duration time.Duration, elapsed time.Duration) *Count {
+	c.Lock()
+	if c.Metrics.Time == nil {
+		c.Metrics.Time = &Time{}
+	}
+	c.Metrics.Time.Duration = duration.Nanoseconds()
+	c.Unlock()
+	num := float64(elapsed.Nanoseconds()) / float64(duration.Nanoseconds())
+	return c.Avg(num).Min(num).Max(num)
+}
+
 type Inc struct {
 	Val  float64 `db:"inc,omitempty" json:"inc,omitempty"`
 	Last float64 `db:"-" json:"-"`
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	pb "github.com/504dev/logr-go-client/protos/gen/go"
+	gojson "github.com/goccy/go-json"
+	"strconv"
+	"strings"
+)
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Fields keeps the order in which attributes were attached, so the console
+// output and the serialized form read the same way as the calling code.
+type Fields []Field
+
+// KV builds Fields from alternating keys and values. Field and Fields
+// arguments are taken as is, a dangling key gets a nil value.
+func KV(kv ...interface{}) Fields {
+	res := make(Fields, 0, len(kv)/2)
+	for i := 0; i < len(kv); i++ {
+		switch v := kv[i].(type) {
+		case Field:
+			res = res.Set(v.Key, v.Value)
+			continue
+		case Fields:
+			res = res.With(v)
+			continue
+		}
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		var value interface{}
+		if i+1 < len(kv) {
+			i++
+			value = kv[i]
+		}
+		res = res.Set(key, value)
+	}
+	return res
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
+// Set returns a copy of the fields with key set to value. An existing key keeps its position.
+func (f Fields) Set(key string, value interface{}) Fields {
+	value = normalizeValue(value)
+	res := make(Fields, len(f), len(f)+1)
+	copy(res, f)
+	for i := range res {
+		if res[i].Key == key {
+			res[i].Value = value
+			return res
+		}
+	}
+	return append(res, Field{Key: key, Value: value})
+}
+
+// With returns a copy of the fields extended by other. Values of other win on duplicate keys.
+func (f Fields) With(other Fields) Fields {
+	if len(other) == 0 {
+		return f
+	}
+	res := f
+	for _, field := range other {
+		res = res.Set(field.Key, field.Value)
+	}
+	return res
+}
+
+func (f Fields) Get(key string) (interface{}, bool) {
+	for _, field := range f {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (f Fields) Map() map[string]interface{} {
+	res := make(map[string]interface{}, len(f))
+	for _, field := range f {
+		res[field.Key] = field.Value
+	}
+	return res
+}
+
+// String renders the fields as space separated key=value pairs.
+func (f Fields) String() string {
+	parts := make([]string, len(f))
+	for i, field := range f {
+		var value string
+		switch v := field.Value.(type) {
+		case string:
+			value = v
+			if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+				value = strconv.Quote(v)
+			}
+		case nil:
+			value = "null"
+		default:
+			value = fmt.Sprint(v)
+		}
+		parts[i] = field.Key + "=" + value
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f Fields) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range f {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := gojson.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := gojson.Marshal(field.Value)
+		if err != nil {
+			value, _ = gojson.Marshal(fmt.Sprint(field.Value))
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	decoder := gojson.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		*f = nil
+		return nil
+	}
+	if delim, ok := token.(gojson.Delim); !ok || delim != '{' {
+		return errors.New("fields: object expected")
+	}
+	res := Fields{}
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		var value interface{}
+		if err = decoder.Decode(&value); err != nil {
+			return err
+		}
+		if number, ok := value.(gojson.Number); ok {
+			if i, err := number.Int64(); err == nil {
+				value = i
+			} else {
+				value, _ = number.Float64()
+			}
+		}
+		res = append(res, Field{Key: key, Value: value})
+	}
+	*f = res
+	return nil
+}
+
+func (f Fields) Proto() []*pb.LogRpcPackage_Field {
+	if len(f) == 0 {
+		return nil
+	}
+	res := make([]*pb.LogRpcPackage_Field, len(f))
+	for i, field := range f {
+		pf := &pb.LogRpcPackage_Field{Key: field.Key}
+		switch v := field.Value.(type) {
+		case nil:
+		case string:
+			pf.Value = &pb.LogRpcPackage_Field_Str{Str: v}
+		case bool:
+			pf.Value = &pb.LogRpcPackage_Field_Bool{Bool: v}
+		case int:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case int8:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case int16:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case int32:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case int64:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: v}
+		case uint:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case uint8:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case uint16:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case uint32:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case uint64:
+			pf.Value = &pb.LogRpcPackage_Field_Int{Int: int64(v)}
+		case float32:
+			pf.Value = &pb.LogRpcPackage_Field_Num{Num: float64(v)}
+		case float64:
+			pf.Value = &pb.LogRpcPackage_Field_Num{Num: v}
+		default:
+			pf.Value = &pb.LogRpcPackage_Field_Str{Str: fmt.Sprint(v)}
+		}
+		res[i] = pf
+	}
+	return res
+}
+
+func FieldsFromProto(pfs []*pb.LogRpcPackage_Field) Fields {
+	if len(pfs) == 0 {
+		return nil
+	}
+	res := make(Fields, len(pfs))
+	for i, pf := range pfs {
+		res[i].Key = pf.Key
+		switch v := pf.Value.(type) {
+		case *pb.LogRpcPackage_Field_Str:
+			res[i].Value = v.Str
+		case *pb.LogRpcPackage_Field_Bool:
+			res[i].Value = v.Bool
+		case *pb.LogRpcPackage_Field_Int:
+			res[i].Value = v.Int
+		case *pb.LogRpcPackage_Field_Num:
+			res[i].Value = v.Num
+		}
+	}
+	return res
+}
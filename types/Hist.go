@@ -0,0 +1,133 @@
+package types
+
+import (
+	"math"
+	"sort"
+)
+
+// HIST_ACCURACY is the relative error of the quantiles of Hist.
+const HIST_ACCURACY = 0.01
+
+// values closer to zero than that are counted in the zero bucket
+const histMinValue = 1e-9
+
+var histGamma = (1 + HIST_ACCURACY) / (1 - HIST_ACCURACY)
+var histLogGamma = math.Log(histGamma)
+
+// Hist is a DDSketch-style mergeable histogram: bucket i holds the values
+// between gamma^(i-1) and gamma^i, so any quantile is known within
+// HIST_ACCURACY whatever the range of the values. Sketches of different
+// windows or hosts are merged by adding up the buckets.
+type Hist struct {
+	Pos  map[int32]uint64 `db:"hist_pos"  json:"hist_pos,omitempty"`
+	Neg  map[int32]uint64 `db:"hist_neg"  json:"hist_neg,omitempty"`
+	Zero uint64           `db:"hist_zero" json:"hist_zero,omitempty"`
+	Sum  float64          `db:"hist_sum"  json:"hist_sum,omitempty"`
+	Num  uint64           `db:"hist_num"  json:"hist_num,omitempty"`
+}
+
+func histIndex(abs float64) int32 {
+	return int32(math.Ceil(math.Log(abs) / histLogGamma))
+}
+
+func histValue(index int32) float64 {
+	return 2 * math.Pow(histGamma, float64(index)) / (histGamma + 1)
+}
+
+// Add counts num in. NaN and infinite values are ignored: they have no
+// bucket and would turn Sum into one of them.
+func (h *Hist) Add(num float64) {
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return
+	}
+	switch {
+	case num > histMinValue:
+		if h.Pos == nil {
+			h.Pos = map[int32]uint64{}
+		}
+		h.Pos[histIndex(num)]++
+	case num < -histMinValue:
+		if h.Neg == nil {
+			h.Neg = map[int32]uint64{}
+		}
+		h.Neg[histIndex(-num)]++
+	default:
+		h.Zero++
+	}
+	h.Sum += num
+	h.Num++
+}
+
+func (h *Hist) Merge(other *Hist) {
+	for i, n := range other.Pos {
+		if h.Pos == nil {
+			h.Pos = map[int32]uint64{}
+		}
+		h.Pos[i] += n
+	}
+	for i, n := range other.Neg {
+		if h.Neg == nil {
+			h.Neg = map[int32]uint64{}
+		}
+		h.Neg[i] += n
+	}
+	h.Zero += other.Zero
+	h.Sum += other.Sum
+	h.Num += other.Num
+}
+
+// Quantile returns the q-quantile (0 <= q <= 1) of the added values.
+func (h *Hist) Quantile(q float64) float64 {
+	if h.Num == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(h.Num-1))
+
+	neg := sortedIndexes(h.Neg)
+	for i := len(neg) - 1; i >= 0; i-- {
+		n := h.Neg[neg[i]]
+		if rank < n {
+			return -histValue(neg[i])
+		}
+		rank -= n
+	}
+	if rank < h.Zero {
+		return 0
+	}
+	rank -= h.Zero
+	pos := sortedIndexes(h.Pos)
+	for _, index := range pos {
+		n := h.Pos[index]
+		if rank < n {
+			return histValue(index)
+		}
+		rank -= n
+	}
+	if len(pos) > 0 {
+		return histValue(pos[len(pos)-1])
+	}
+	return 0
+}
+
+func sortedIndexes(buckets map[int32]uint64) []int32 {
+	res := make([]int32, 0, len(buckets))
+	for i := range buckets {
+		res = append(res, i)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// Value is the median.
+func (h *Hist) Value() float64 {
+	return h.Quantile(0.5)
+}
+
+func (h *Hist) Percentiles() map[string]float64 {
+	return map[string]float64{
+		"p50": h.Quantile(0.5),
+		"p90": h.Quantile(0.9),
+		"p95": h.Quantile(0.95),
+		"p99": h.Quantile(0.99),
+	}
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Labels are the dimensions of a counter, like route or status code.
+type Labels map[string]string
+
+// MergeLabels returns the union of the label sets, later values win.
+func MergeLabels(sets ...Labels) Labels {
+	var res Labels
+	for _, labels := range sets {
+		for k, v := range labels {
+			if res == nil {
+				res = Labels{}
+			}
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// String is the canonical form of the labels, sorted by name:
+// code="200",route="/api". Equal label sets give equal strings.
+func (l Labels) String() string {
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(l[k]))
+	}
+	return sb.String()
+}
+
+// Key is the counter state key of keyname with the labels.
+func (l Labels) Key(keyname string) string {
+	if len(l) == 0 {
+		return keyname
+	}
+	return keyname + "{" + l.String() + "}"
+}
@@ -14,6 +14,7 @@ type Log struct {
 	Pid       int    `db:"pid"       json:"pid"`
 	Version   string `db:"version"   json:"version,omitempty"`
 	Initiator string `db:"initiator" json:"initiator,omitempty"`
+	Fields    Fields `db:"fields"    json:"fields,omitempty"`
 }
 
 type Logs []*Log
@@ -22,6 +23,14 @@ func (log *Log) Decrypt(cipherBytes []byte, priv string) error {
 	return cipher.DecodeAesJson(cipherBytes, priv, log)
 }
 
+func (log *Log) DecryptMode(cipherBytes []byte, priv string, mode cipher.Mode) error {
+	return cipher.DecodeAesJsonMode(cipherBytes, priv, log, mode)
+}
+
 func (log *Log) Encrypt(priv string) ([]byte, error) {
 	return cipher.EncryptAesJson(log, priv)
 }
+
+func (log *Log) EncryptMode(priv string, mode cipher.Mode) ([]byte, error) {
+	return cipher.EncryptAesJsonMode(log, priv, mode)
+}
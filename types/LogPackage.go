@@ -69,7 +69,11 @@ func (lp *LogPackage) DeserializeLog() error {
 }
 
 func (lp *LogPackage) EncryptLog(priv string) error {
-	cipherLog, err := lp.Log.Encrypt(priv)
+	return lp.EncryptLogMode(priv, cipher.ModeCfb)
+}
+
+func (lp *LogPackage) EncryptLogMode(priv string, mode cipher.Mode) error {
+	cipherLog, err := lp.Log.EncryptMode(priv, mode)
 	if err != nil {
 		return err
 	}
@@ -78,8 +82,12 @@ func (lp *LogPackage) EncryptLog(priv string) error {
 	return nil
 }
 func (lp *LogPackage) DecryptLog(priv string) error {
+	return lp.DecryptLogMode(priv, cipher.ModeAuto)
+}
+
+func (lp *LogPackage) DecryptLogMode(priv string, mode cipher.Mode) error {
 	log := Log{}
-	err := log.Decrypt(lp.CipherLog, priv)
+	err := log.DecryptMode(lp.CipherLog, priv, mode)
 	if err != nil {
 		return err
 	}
@@ -88,7 +96,11 @@ func (lp *LogPackage) DecryptLog(priv string) error {
 }
 
 func (lp *LogPackage) EncryptCount(priv string) error {
-	cipherText, err := lp.Count.Encrypt(priv)
+	return lp.EncryptCountMode(priv, cipher.ModeCfb)
+}
+
+func (lp *LogPackage) EncryptCountMode(priv string, mode cipher.Mode) error {
+	cipherText, err := lp.Count.EncryptMode(priv, mode)
 	if err != nil {
 		return err
 	}
@@ -97,8 +109,12 @@ func (lp *LogPackage) EncryptCount(priv string) error {
 	return nil
 }
 func (lp *LogPackage) DecryptCount(priv string) error {
+	return lp.DecryptCountMode(priv, cipher.ModeAuto)
+}
+
+func (lp *LogPackage) DecryptCountMode(priv string, mode cipher.Mode) error {
 	count := Count{}
-	err := count.Decrypt(lp.CipherCount, priv)
+	err := count.DecryptMode(lp.CipherCount, priv, mode)
 	if err != nil {
 		return err
 	}
@@ -194,26 +210,28 @@ func (lp *LogPackage) FromProto(lrp *pb.LogRpcPackage) {
 			Message:   lrp.Log.Message,
 			Version:   lrp.Log.Version,
 			Initiator: lrp.Log.Initiator,
+			Fields:    FieldsFromProto(lrp.Log.Fields),
 		}
 	}
-	if lp.Count != nil {
+	if lrp.Count != nil {
 		lp.Count = &Count{
-			DashId:    int(lrp.Log.DashId),
+			DashId:    int(lrp.Count.DashId),
 			Timestamp: lrp.Count.Timestamp,
 			Hostname:  lrp.Count.Hostname,
 			Version:   lrp.Count.Version,
 			Logname:   lrp.Count.Logname,
 			Keyname:   lrp.Count.Keyname,
+			Labels:    lrp.Count.Labels,
 			Metrics:   Metrics{},
 		}
 		if v := lrp.Count.Inc; v != nil {
-			lp.Count.Inc(v.Inc)
+			lp.Count.Metrics.Inc = &Inc{Val: v.Inc}
 		}
 		if v := lrp.Count.Max; v != nil {
-			lp.Count.Max(v.Max)
+			lp.Count.Metrics.Max = &Max{Val: v.Max}
 		}
 		if v := lrp.Count.Min; v != nil {
-			lp.Count.Min(v.Min)
+			lp.Count.Metrics.Min = &Min{Val: v.Min}
 		}
 		if v := lrp.Count.Avg; v != nil {
 			lp.Count.Metrics.Avg = &Avg{v.Sum, int(v.Num)}
@@ -224,6 +242,9 @@ func (lp *LogPackage) FromProto(lrp *pb.LogRpcPackage) {
 		if v := lrp.Count.Time; v != nil {
 			lp.Count.Metrics.Time = &Time{v.Duration}
 		}
+		if v := lrp.Count.Hist; v != nil {
+			lp.Count.Metrics.Hist = &Hist{Pos: v.Pos, Neg: v.Neg, Zero: v.Zero, Sum: v.Sum, Num: v.Num}
+		}
 	}
 }
 
@@ -246,16 +267,18 @@ func (lp *LogPackage) Proto() *pb.LogRpcPackage {
 			Message:   lp.Log.Message,
 			Version:   lp.Log.Version,
 			Initiator: lp.Log.Initiator,
+			Fields:    lp.Log.Fields.Proto(),
 		}
 	}
 	if lp.Count != nil {
 		res.Count = &pb.LogRpcPackage_Count{
-			DashId:    uint32(lp.Log.DashId),
+			DashId:    uint32(lp.Count.DashId),
 			Timestamp: lp.Count.Timestamp,
 			Hostname:  lp.Count.Hostname,
 			Version:   lp.Count.Version,
 			Logname:   lp.Count.Logname,
 			Keyname:   lp.Count.Keyname,
+			Labels:    lp.Count.Labels,
 		}
 		if v := lp.Count.Metrics.Inc; v != nil {
 			res.Count.Inc = &pb.LogRpcPackage_Count_Inc{Inc: v.Val}
@@ -275,6 +298,9 @@ func (lp *LogPackage) Proto() *pb.LogRpcPackage {
 		if v := lp.Count.Metrics.Time; v != nil {
 			res.Count.Time = &pb.LogRpcPackage_Count_Time{Duration: v.Duration}
 		}
+		if v := lp.Count.Metrics.Hist; v != nil {
+			res.Count.Hist = &pb.LogRpcPackage_Count_Hist{Pos: v.Pos, Neg: v.Neg, Zero: v.Zero, Sum: v.Sum, Num: v.Num}
+		}
 	}
 	return res
 }
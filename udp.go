@@ -0,0 +1,104 @@
+package logr_go_client
+
+import (
+	"context"
+	"github.com/504dev/logr-go-client/types"
+	gojson "github.com/goccy/go-json"
+)
+
+// UdpSink sends packages to the logr server over UDP, logs longer than
+// MAX_MESSAGE_SIZE are split into chunks.
+type UdpSink struct {
+	*Config
+	conn *connection
+}
+
+// NewUdpSink dials conf.Udp. If that fails the error is returned along with
+// the sink, which keeps reconnecting in the background.
+func NewUdpSink(conf *Config) (*UdpSink, error) {
+	conn, err := dial(conf, "udp", conf.Udp)
+	return &UdpSink{Config: conf, conn: conn}, err
+}
+
+func (us *UdpSink) ConnState() ConnState {
+	return us.conn.connState()
+}
+
+func (us *UdpSink) write(msg []byte) error {
+	return us.retry(context.Background(), us.conn.closed, func() error {
+		return us.conn.write(msg)
+	})
+}
+
+// UdpLogMessages makes the UDP messages a log is sent in: the package,
+// encrypted unless NoCipher is set, split into chunks of MAX_MESSAGE_SIZE.
+func (c *Config) UdpLogMessages(log *types.Log) ([][]byte, error) {
+	lp, err := c.pack(queueItem{log: log})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.NoCipher == true {
+		err := lp.SerializeLog()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks, err := lp.Chunkify(MAX_MESSAGE_SIZE, c.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return chunks.Marshal()
+}
+
+// UdpCountMessage makes the UDP message a count is sent in.
+func (c *Config) UdpCountMessage(count *types.Count) ([]byte, error) {
+	lp, err := c.pack(queueItem{count: count})
+	if err != nil {
+		return nil, err
+	}
+	return gojson.Marshal(lp)
+}
+
+func (us *UdpSink) PushLog(log *types.Log) (int, error) {
+	messages, err := us.UdpLogMessages(log)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		err = us.write(msg)
+		//fmt.Println(err, len(chunk))
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
+
+func (us *UdpSink) PushCount(count *types.Count) (int, error) {
+	msg, err := us.UdpCountMessage(count)
+	if err != nil {
+		return 0, err
+	}
+	err = us.write(msg)
+	if err != nil {
+		return 0, err
+	}
+	return len(msg), nil
+}
+
+func (us *UdpSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (us *UdpSink) shutdown() {
+	us.conn.shutdown()
+}
+
+func (us *UdpSink) Close() error {
+	return us.conn.close()
+}
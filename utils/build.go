@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"regexp"
+	"runtime/debug"
+	"time"
+)
+
+type BuildInfo struct {
+	Path     string    // of the main module
+	Version  string    // of the main module, "" for (devel)
+	Revision string    // of the commit the binary was built from
+	Time     time.Time // of the commit
+	Modified bool      // whether there were uncommitted changes
+	Tag      string    // from git, only known with the git fallback
+}
+
+// ReadBuildInfo reads the build info embedded into the binary by the go
+// command. Revision, Time and Modified are there when the binary is built
+// with go build in a repository, but not with go run or go test.
+func ReadBuildInfo() BuildInfo {
+	res := BuildInfo{}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return res
+	}
+	res.Path = info.Main.Path
+	if info.Main.Version != "(devel)" {
+		res.Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			res.Revision = setting.Value
+		case "vcs.time":
+			res.Time, _ = time.Parse(time.RFC3339, setting.Value)
+		case "vcs.modified":
+			res.Modified = setting.Value == "true"
+		}
+	}
+	return res
+}
+
+// ReadGitBuildInfo asks git for the revision and the tag of the working directory.
+func ReadGitBuildInfo() BuildInfo {
+	return BuildInfo{Revision: ReadGitCommit(), Tag: ReadGitTag()}
+}
+
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}`)
+
+// ShortVersion is the tag, the released version of the main module or
+// the first 6 characters of the revision, the first of them known.
+func (b BuildInfo) ShortVersion() string {
+	if b.Tag != "" {
+		return b.Tag
+	}
+	if b.Version != "" && !pseudoVersion.MatchString(b.Version) {
+		return b.Version
+	}
+	if len(b.Revision) >= 6 {
+		return b.Revision[:6]
+	}
+	return ""
+}
@@ -5,7 +5,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"runtime/debug"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -23,13 +24,39 @@ func ReadGitCommitDir(dir string) string {
 	cmd.Dir = dir
 	stdout, err := cmd.Output()
 	if err != nil {
-		cmd := exec.Command("cat", ".git/HEAD")
-		cmd.Dir = dir
-		stdout, _ = cmd.Output()
+		return ReadGitHeadDir(dir)
 	}
-	commit := strings.TrimSuffix(string(stdout), "\n")
+	return strings.TrimSpace(string(stdout))
+}
 
-	return commit
+var commitHash = regexp.MustCompile(`^[0-9a-f]{40}(?:[0-9a-f]{24})?$`)
+
+// ReadGitHeadDir resolves .git/HEAD of dir without git: a branch is looked
+// up in .git/refs and .git/packed-refs. It returns "" if there is no commit.
+func ReadGitHeadDir(dir string) string {
+	gitDir := filepath.Join(dir, ".git")
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	hash := strings.TrimSpace(string(head))
+	if ref := strings.TrimPrefix(hash, "ref: "); ref != hash {
+		hash = ""
+		if data, err := os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+			hash = strings.TrimSpace(string(data))
+		} else if packed, err := os.ReadFile(filepath.Join(gitDir, "packed-refs")); err == nil {
+			for _, line := range strings.Split(string(packed), "\n") {
+				if fields := strings.Fields(line); len(fields) == 2 && fields[1] == ref {
+					hash = fields[0]
+					break
+				}
+			}
+		}
+	}
+	if !commitHash.MatchString(hash) {
+		return ""
+	}
+	return hash
 }
 
 func ReadGitTag() string {
@@ -48,22 +75,64 @@ func ReadGitTagDir(dir string) string {
 	return ""
 }
 
+// Initiator is the caller of the caller of the caller of the caller of Initiator.
+//
+// Deprecated: use Caller, which takes the depth.
 func Initiator() (string, string) {
-	stack := string(debug.Stack())
-	stackSplitted := strings.Split(stack, "\n")
-	caller := strings.TrimSpace(stackSplitted[11])
-	initiator := strings.TrimSpace(stackSplitted[12])
+	return Caller(4)
+}
+
+// Caller returns the "dir/file.go:line" and the "pkg.Func" of a function on
+// the stack, 0 is the caller of Caller.
+func Caller(skip int) (initiator string, caller string) {
+	return CallerOutside(skip + 1)
+}
 
-	start, end := strings.LastIndex(caller, "/"), strings.LastIndex(caller, "(")
-	callerSplitted := strings.Split(caller[start+1:end], ".")
-	if length := len(callerSplitted); length > 2 {
-		callerSplitted = callerSplitted[length-2 : length]
+// CallerOutside is Caller which also skips the frames of the given packages,
+// e.g. "fmt" for the callers of an io.Writer.
+func CallerOutside(skip int, packages ...string) (initiator string, caller string) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !inPackages(frame.Function, packages) {
+			return FrameInitiator(frame), FrameCaller(frame)
+		}
+		if !more {
+			return "", ""
+		}
 	}
-	caller = strings.Join(callerSplitted, ".")
+}
 
-	initiatorSplitted := regexp.MustCompile(`[\s/]+`).Split(initiator, 20)
-	length := len(initiatorSplitted)
-	initiator = strings.Join(initiatorSplitted[length-3:length-1], "/")
+// FramePC is Caller for a program counter, like the one of slog.Record.
+func FramePC(pc uintptr) (initiator string, caller string) {
+	if pc == 0 {
+		return "", ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return FrameInitiator(frame), FrameCaller(frame)
+}
+
+func FrameInitiator(frame runtime.Frame) string {
+	dir, file := filepath.Split(frame.File)
+	return filepath.Base(dir) + "/" + file + ":" + strconv.Itoa(frame.Line)
+}
 
-	return initiator, caller
+func FrameCaller(frame runtime.Frame) string {
+	function := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+	parts := strings.Split(function, ".")
+	if length := len(parts); length > 2 {
+		parts = parts[length-2 : length]
+	}
+	return strings.Join(parts, ".")
+}
+
+func inPackages(function string, packages []string) bool {
+	for _, pkg := range packages {
+		if strings.HasPrefix(function, pkg+".") {
+			return true
+		}
+	}
+	return false
 }
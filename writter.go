@@ -1,7 +1,13 @@
 package logr_go_client
 
 import (
+	"bytes"
 	"github.com/504dev/logr-go-client/types"
+	"github.com/504dev/logr-go-client/utils"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 type Writer struct {
@@ -13,6 +19,8 @@ func (w *Writer) Write(b []byte) (int, error) {
 	log := w.blankLog()
 	log.Level = types.LevelInfo
 	log.Message = string(b)
+	log.Fields = w.Fields
+	log.Initiator, _ = utils.CallerOutside(1+w.callerSkip, "fmt", "io", "bufio")
 
 	if w.Transform != nil {
 		w.Transform(&Log{Log: log})
@@ -24,3 +32,149 @@ func (w *Writer) Write(b []byte) (int, error) {
 type Log struct {
 	*types.Log
 }
+
+// LevelRule gives Level to the lines matching Pattern. With Strip the match
+// is removed from the message.
+type LevelRule struct {
+	Pattern *regexp.Regexp
+	Level   types.Level
+	Strip   bool
+}
+
+// levelToken matches the tokens in brackets, in upper case or followed by a colon.
+func levelToken(level types.Level, tokens string) LevelRule {
+	upper := strings.ToUpper(tokens)
+	return LevelRule{
+		Pattern: regexp.MustCompile(`^\s*(?:\[(?i:` + tokens + `)\]|(?:` + upper + `)\b:?|(?i:` + tokens + `):)\s*`),
+		Level:   level,
+		Strip:   true,
+	}
+}
+
+// DefaultLevelRules detect a level token at the start of a line: "ERROR x",
+// "[warn] x", "debug: x" and so on, but not "error x".
+var DefaultLevelRules = []LevelRule{
+	levelToken(types.LevelEmerg, "emerg|emergency|panic"),
+	levelToken(types.LevelAlert, "alert"),
+	levelToken(types.LevelCrit, "crit|critical|fatal"),
+	levelToken(types.LevelError, "err|error"),
+	levelToken(types.LevelWarn, "warn|warning"),
+	levelToken(types.LevelNotice, "notice"),
+	levelToken(types.LevelInfo, "info"),
+	levelToken(types.LevelDebug, "debug|trace"),
+}
+
+// stdTimestamp matches the date and time the log package puts before its lines.
+var stdTimestamp = regexp.MustCompile(`^(?:\d{4}/\d{2}/\d{2} )?(?:\d{2}:\d{2}:\d{2}(?:\.\d+)? )?`)
+
+var stdFileLine = regexp.MustCompile(`^(\S+\.go:\d+): `)
+
+// LineWriter logs every line written to it as a separate log, so it can
+// take the place of os.Stderr: in log.SetOutput, exec.Cmd.Stderr and such.
+// An incomplete line is kept until the rest of it is written or until Close,
+// up to MAX_MESSAGE_SIZE bytes: longer lines are logged in parts.
+type LineWriter struct {
+	Logger *Logger
+	Level  types.Level // of the lines no rule matches, LevelInfo if empty
+	Rules  []LevelRule // the first matching rule gives the level of a line
+	// StripStdTimestamp removes the timestamps of the log package, the logs have their own
+	StripStdTimestamp bool
+	// StdFileLine moves the "file.go:12: " of log.Lshortfile or log.Llongfile to the initiator
+	StdFileLine bool
+	Transform   func(log *Log)
+	mu          sync.Mutex
+	buf         []byte
+}
+
+// LineWriter returns a writer detecting levels with DefaultLevelRules and
+// stripping the timestamps of the log package, level is for the other lines.
+func (lg *Logger) LineWriter(level types.Level) *LineWriter {
+	return &LineWriter{
+		Logger:            lg,
+		Level:             level,
+		Rules:             DefaultLevelRules,
+		StripStdTimestamp: true,
+	}
+}
+
+func (w *LineWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, b...)
+	var err error
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		next := i + 1
+		if i < 0 || i > MAX_MESSAGE_SIZE {
+			if len(w.buf) < MAX_MESSAGE_SIZE {
+				break
+			}
+			// a line too long to wait for its end is logged in parts
+			i = MAX_MESSAGE_SIZE
+			for j := i; i < len(w.buf) && j > i-utf8.UTFMax && j > 0; j-- {
+				if utf8.RuneStart(w.buf[j]) {
+					i = j
+					break
+				}
+			}
+			next = i
+		}
+		if lineErr := w.logLine(w.buf[:i]); err == nil {
+			err = lineErr
+		}
+		w.buf = w.buf[next:]
+	}
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+	return len(b), err
+}
+
+// Close logs the incomplete line if there is one, the logger stays open.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.logLine(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *LineWriter) logLine(line []byte) error {
+	line = bytes.TrimRight(line, "\r")
+	if w.StripStdTimestamp {
+		line = stdTimestamp.ReplaceAll(line, nil)
+	}
+	initiator := ""
+	if w.StdFileLine {
+		if m := stdFileLine.FindSubmatch(line); m != nil {
+			initiator = string(m[1])
+			line = line[len(m[0]):]
+		}
+	}
+	level := w.Level
+	if level == "" {
+		level = types.LevelInfo
+	}
+	for _, rule := range w.Rules {
+		if loc := rule.Pattern.FindIndex(line); loc != nil {
+			level = rule.Level
+			if rule.Strip {
+				line = append(line[:loc[0]:loc[0]], line[loc[1]:]...)
+			}
+			break
+		}
+	}
+	if len(bytes.TrimSpace(line)) == 0 || !w.Logger.enabled(level) {
+		return nil
+	}
+	log := w.Logger.newLog(level, string(line), w.Logger.Fields)
+	log.Initiator = initiator
+	if w.Transform != nil {
+		w.Transform(&Log{Log: log})
+	}
+	_, err := w.Logger.emit(log)
+	return err
+}